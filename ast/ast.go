@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"esolang/lang-esolang/token"
 	"fmt"
+	"math/big"
+	"regexp"
 	"strings"
 )
 
@@ -141,6 +143,19 @@ func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
+/*
+BigIntegerLiteral represents an integer literal too large for an int64.
+For example, `99999999999999999999`.
+*/
+type BigIntegerLiteral struct {
+	Token token.Token
+	Value *big.Int
+}
+
+func (bl *BigIntegerLiteral) expressionNode()      {}
+func (bl *BigIntegerLiteral) TokenLiteral() string { return bl.Token.Literal }
+func (bl *BigIntegerLiteral) String() string       { return bl.Token.Literal }
+
 // FloatLiteral holds a floating-point number
 // For example, `5.0`.
 type FloatLiteral struct {
@@ -278,6 +293,7 @@ type FunctionLiteral struct {
 	Token      token.Token
 	Parameters []*Identifier
 	Body       *BlockStatement
+	Async      bool // declared with `async fn`, calls return a task
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
@@ -291,6 +307,9 @@ func (fl *FunctionLiteral) String() string {
 		params = append(params, p.String())
 	}
 
+	if fl.Async {
+		out.WriteString("async ")
+	}
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ","))
@@ -461,6 +480,77 @@ func (ie *ImportExpression) String() string {
 	return out.String()
 }
 
+// SpawnExpression runs a function, a call or a command concurrently and evaluates
+// to its task. `async` before a command literal is parsed as a spawn too.
+type SpawnExpression struct {
+	Token token.Token // The 'spawn' token
+	Task  Expression
+}
+
+func (se *SpawnExpression) expressionNode() {}
+
+// TokenLiteral prints the literal value of the token associated with this node
+func (se *SpawnExpression) TokenLiteral() string { return se.Token.Literal }
+
+// String returns a stringified version of the AST for debugging
+func (se *SpawnExpression) String() string {
+	return "(" + se.TokenLiteral() + " " + se.Task.String() + ")"
+}
+
+// AwaitExpression waits for a task and evaluates to its result.
+type AwaitExpression struct {
+	Token token.Token // The 'await' token
+	Value Expression
+}
+
+func (ae *AwaitExpression) expressionNode() {}
+
+// TokenLiteral prints the literal value of the token associated with this node
+func (ae *AwaitExpression) TokenLiteral() string { return ae.Token.Literal }
+
+// String returns a stringified version of the AST for debugging
+func (ae *AwaitExpression) String() string {
+	return "(await " + ae.Value.String() + ")"
+}
+
+// ImportStatement binds a module, or some of its exported names, in the current scope:
+//
+//	import "eso/string" as s
+//	from "eso/math" import Sqrt, PI as pi
+type ImportStatement struct {
+	Token token.Token // The 'import' or 'from' token
+	Path  *StringLiteral
+	Alias *Identifier     // the module's name for `import ... as`
+	Names []*ImportedName // the names taken by `from ... import`
+}
+
+// ImportedName is a name taken from a module, bound as Alias.
+type ImportedName struct {
+	Name  *Identifier
+	Alias *Identifier
+}
+
+func (is *ImportStatement) statementNode() {}
+
+// TokenLiteral prints the literal value of the token associated with this node
+func (is *ImportStatement) TokenLiteral() string { return is.Token.Literal }
+
+// String returns a stringified version of the AST for debugging
+func (is *ImportStatement) String() string {
+	if is.Alias != nil {
+		return fmt.Sprintf("import %q as %s;", is.Path.Value, is.Alias)
+	}
+	names := make([]string, 0, len(is.Names))
+	for _, n := range is.Names {
+		if n.Alias.Value != n.Name.Value {
+			names = append(names, n.Name.Value+" as "+n.Alias.Value)
+		} else {
+			names = append(names, n.Name.Value)
+		}
+	}
+	return fmt.Sprintf("from %q import %s;", is.Path.Value, strings.Join(names, ", "))
+}
+
 type BindExpression struct {
 	Token token.Token // The := token
 	Left  Expression
@@ -495,6 +585,9 @@ type FunctionDefineLiteral struct {
 
 	// Body holds the set of statements in the functions' body.
 	Body *BlockStatement
+
+	// Async is set for `async func`, calls return a task.
+	Async bool
 }
 
 func (fl *FunctionDefineLiteral) expressionNode() {}
@@ -511,6 +604,9 @@ func (fl *FunctionDefineLiteral) String() string {
 	for _, p := range fl.Parameters {
 		params = append(params, p.String())
 	}
+	if fl.Async {
+		out.WriteString("async ")
+	}
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
@@ -578,3 +674,24 @@ func (bl *BacktickLiteral) TokenLiteral() string { return bl.Token.Literal }
 
 // String returns this object as a string.
 func (bl *BacktickLiteral) String() string { return bl.Token.Literal }
+
+// RegexLiteral holds a regular expression pattern.
+// For example, `re"[a-z]+"`.
+type RegexLiteral struct {
+	// Token is the actual token
+	Token token.Token
+
+	// Value is the uncompiled pattern.
+	Value string
+
+	// Regexp is the pattern compiled by the parser, shared by every evaluation.
+	Regexp *regexp.Regexp
+}
+
+func (rl *RegexLiteral) expressionNode() {}
+
+// TokenLiteral returns the literal token.
+func (rl *RegexLiteral) TokenLiteral() string { return rl.Token.Literal }
+
+// String returns this object as a string.
+func (rl *RegexLiteral) String() string { return fmt.Sprintf("re\"%s\"", rl.Value) }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"esolang/lang-esolang/bundle"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/repl"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// buildCommand bundles a script and the modules it imports into a standalone executable.
+//
+//	esolang build app.eso [-o app]
+func buildCommand(args []string) error {
+	flags := flag.NewFlagSet("build", flag.ContinueOnError)
+	output := flags.String("o", "", "the executable to write, the script name without .eso by default")
+	// accept the flags before or after the script
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("usage: esolang build <script.eso> [-o output]")
+	}
+	entry := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.New("usage: esolang build <script.eso> [-o output]")
+	}
+	if filepath.Ext(entry) != ".eso" {
+		return errors.New("Invalid file extension. Please provide a file with .eso extension")
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(filepath.Base(entry), ".eso")
+	}
+
+	logger := log.New(os.Stderr)
+	loadPackages(filepath.Dir(entry), logger)
+	b, warnings, err := bundle.Build(entry)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		logger.Warn(w)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := b.Write(exe, *output); err != nil {
+		return err
+	}
+	fmt.Printf("built %s with %d modules\n", *output, len(b.Files))
+	return nil
+}
+
+// runBundle runs the program bundled into this executable, if there is one.
+// Every command line argument belongs to the program.
+func runBundle() bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	b, err := bundle.Open(exe)
+	if err != nil {
+		log.New(os.Stderr).Error(err.Error())
+		os.Exit(1)
+	}
+	if b == nil {
+		return false
+	}
+	b.Install(filepath.Dir(exe))
+	object.DefaultRuntime.Args = append([]string{b.Entry}, os.Args[1:]...)
+	repl.Execute(b.Entry, b.Files[b.Entry], os.Args[1:])
+	return true
+}
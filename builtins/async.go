@@ -0,0 +1,92 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+)
+
+// asyncBuiltins maps the name of an async builtin to the blocking builtin it runs in a task.
+var asyncBuiltins = map[string]string{
+	"ReadFileAsync":       "ReadFile",
+	"WriteFileAsync":      "WriteFile",
+	"HttpAsync":           "Http",
+	"http_request_async":  "http_request",
+	"http_get_async":      "http_get",
+	"http_post_async":     "http_post",
+	"http_put_async":      "http_put",
+	"http_patch_async":    "http_patch",
+	"http_delete_async":   "http_delete",
+	"fs_read_lines_async": "fs_read_lines",
+	"process_spawn_async": "process_spawn",
+	"csv_read_async":      "csv_read",
+}
+
+func init() {
+	for name, blocking := range asyncBuiltins {
+		RegisterRuntimeBuiltin(name, asyncBuiltin(blocking))
+	}
+	RegisterRuntimeBuiltin("async_all", asyncAll)
+	RegisterRuntimeBuiltin("async_race", asyncRace)
+}
+
+// asyncBuiltin returns a builtin that starts the named builtin in a task and
+// returns the task right away. The name is resolved on call, so the order in
+// which builtins register does not matter.
+func asyncBuiltin(name string) object.RuntimeFunction {
+	return func(rt *object.Runtime, args ...object.Object) object.Object {
+		return object.NewTask(func() object.Object {
+			return Builtins[name].Call(rt, args...)
+		})
+	}
+}
+
+// taskArgs reads an array of tasks, plain values are accepted as already settled.
+func taskArgs(name string, args []object.Object) ([]*object.Task, object.Object) {
+	if err := object.CheckTypings(
+		name, args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.ARRAY_OBJ),
+	); err != nil {
+		return nil, object.NewErrorFromTypings(err.Error())
+	}
+	elements := args[0].(*object.Array).Elements
+	tasks := make([]*object.Task, len(elements))
+	for i, el := range elements {
+		if task, ok := el.(*object.Task); ok {
+			tasks[i] = task
+			continue
+		}
+		value := el
+		tasks[i] = object.NewTask(func() object.Object { return value })
+	}
+	return tasks, nil
+}
+
+/*
+asyncAll waits for every task and returns their results in the same order
+
+	@param tasks array - tasks, or plain values which are passed through
+	@exception the first error raised by a task, in array order
+*/
+func asyncAll(rt *object.Runtime, args ...object.Object) object.Object {
+	tasks, errObj := taskArgs("All", args)
+	if errObj != nil {
+		return errObj
+	}
+	return object.WaitAll(rt, tasks)
+}
+
+/*
+asyncRace returns the result, or error, of the first task to finish
+
+	@param tasks array - at least one task
+*/
+func asyncRace(rt *object.Runtime, args ...object.Object) object.Object {
+	tasks, errObj := taskArgs("Race", args)
+	if errObj != nil {
+		return errObj
+	}
+	if len(tasks) == 0 {
+		return newError("ValueError: Race() needs at least one task")
+	}
+	return object.WaitFirst(rt, tasks)
+}
@@ -0,0 +1,76 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"math/big"
+	"strings"
+)
+
+func init() {
+	RegisterBuiltin("BigInt", bigInt)
+	RegisterBuiltin("Decimal", decimal)
+}
+
+/*
+bigInt converts a string or integer to an arbitrary-precision integer
+
+	@param value String (e.g "123456789012345678901234567890", "0xff") or Integer
+	@exception wrong number of arguments.
+	@exception ValueError: the string is not an integer
+*/
+func bigInt(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"BigInt", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+
+	switch arg := args[0].(type) {
+	case *object.Integer:
+		return object.NewBigInt(big.NewInt(arg.Value))
+	case *object.BigInt:
+		return arg
+	case *object.Decimal:
+		return arg.InvokeMethod("to_int", *object.NewEnvironment())
+	case *object.String:
+		n, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 0)
+		if !ok {
+			return newError("ValueError: invalid integer %q", arg.Value)
+		}
+		return object.NewBigInt(n)
+	default:
+		return newError("TypeError: BigInt() cannot convert `%s`", args[0].Type())
+	}
+}
+
+/*
+decimal converts a value to an exact base-10 Decimal
+
+	@param value String (e.g "19.99"), Integer, BigInt, Float or Decimal
+	@exception wrong number of arguments.
+	@exception ValueError: the string is not a decimal number
+*/
+func decimal(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Decimal", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+
+	if s, ok := args[0].(*object.String); ok {
+		d, err := object.ParseDecimal(s.Value)
+		if err != nil {
+			return newError("ValueError: %s", err)
+		}
+		return d
+	}
+	if f, ok := args[0].(*object.Float); ok && (f.Inspect() == "NaN" || strings.HasSuffix(f.Inspect(), "Inf")) {
+		return newError("ValueError: cannot convert %s to a Decimal", f.Inspect())
+	}
+	if d, ok := object.ToDecimal(args[0]); ok {
+		return d
+	}
+	return newError("TypeError: Decimal() cannot convert `%s`", args[0].Type())
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
-	"time"
 )
 
 var NULL = &object.Null{}
@@ -51,19 +50,19 @@ var Builtins = map[string]*object.Builtin{
 		Fn: arrayRest,
 	},
 	"println": &object.Builtin{
-		Fn: Println,
+		RuntimeFn: Println,
 	},
 	"print": &object.Builtin{
-		Fn: Print,
+		RuntimeFn: Print,
 	},
 	"ReadFile": &object.Builtin{
-		Fn: readFile,
+		RuntimeFn: readFile,
 	},
 	"WriteFile": &object.Builtin{
-		Fn: writeFile,
+		RuntimeFn: writeFile,
 	},
 	"Http": &object.Builtin{
-		Fn: _http,
+		RuntimeFn: requires((*object.Runtime).CheckNet, _http),
 	},
 	"type_of": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
@@ -101,14 +100,12 @@ var Builtins = map[string]*object.Builtin{
 
 	"math_randomInt": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			rand.Seed(time.Now().UnixNano())
 			return &object.Integer{Value: rand.Int63()}
 		},
 	},
 
 	"math_randomFloat": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			rand.Seed(time.Now().UnixNano())
 			return &object.Float{Value: rand.Float64()}
 		},
 	},
@@ -130,6 +127,33 @@ func RegisterBuiltin(name string, fun object.BuiltinFunction) {
 	Builtins[name] = &object.Builtin{Fn: fun}
 }
 
+// RegisterFunc registers an ordinary Go function as a builtin, object.NewGoFunction
+// describes how its arguments and results are converted. It panics when fn
+// cannot be wrapped, registering happens in init functions.
+func RegisterFunc(name string, fn any) {
+	builtin, err := object.NewGoFunction(name, fn)
+	if err != nil {
+		panic(fmt.Sprintf("builtins: %s: %s", name, err))
+	}
+	Builtins[name] = builtin
+}
+
+// RegisterRuntimeBuiltin registers a builtin that prints or uses files.
+func RegisterRuntimeBuiltin(name string, fun object.RuntimeFunction) {
+	Builtins[name] = &object.Builtin{RuntimeFn: fun}
+}
+
+// requires returns fun as a runtime builtin that raises a PermissionError
+// instead of running when check, e.g. (*object.Runtime).CheckNet, denies it.
+func requires(check func(*object.Runtime) error, fun object.BuiltinFunction) object.RuntimeFunction {
+	return func(rt *object.Runtime, args ...object.Object) object.Object {
+		if err := check(rt); err != nil {
+			return newError("PermissionError: %s", err)
+		}
+		return fun(args...)
+	}
+}
+
 func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
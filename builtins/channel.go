@@ -0,0 +1,64 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"time"
+)
+
+func init() {
+	RegisterBuiltin("Channel", channel)
+	RegisterRuntimeBuiltin("select", selectChannels)
+}
+
+/*
+channel creates a Channel to pass values between spawned tasks
+
+	@param size integer (optional) - how many values are buffered before send blocks, 0 by default
+*/
+func channel(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Channel", args,
+		object.RangeOfArgs(0, 1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	size := int64(0)
+	if len(args) == 1 {
+		size = args[0].(*object.Integer).Value
+	}
+	if size < 0 {
+		return newError("ValueError: Channel() size must not be negative, got %d", size)
+	}
+	return object.NewChannel(int(size))
+}
+
+/*
+selectChannels waits for the first ready case
+
+	@param cases array - channels to receive from or [channel, value] pairs to send
+	@param timeout integer|duration (optional) - give up after this long, 0 returns at once
+	@return hash {"index", "value", "ok"}, index is -1 on timeout
+*/
+func selectChannels(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"select", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.ARRAY_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	cases := args[0].(*object.Array).Elements
+	var timeout *time.Duration
+	if len(args) == 2 {
+		d, errObj := object.ToDuration(args[1])
+		if errObj != nil {
+			return errObj
+		}
+		timeout = &d
+	}
+	if len(cases) == 0 && timeout == nil {
+		return newError("ValueError: select() without cases or timeout would block forever")
+	}
+	return object.Select(rt, cases, timeout)
+}
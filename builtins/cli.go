@@ -0,0 +1,28 @@
+package builtins
+
+import "esolang/lang-esolang/object"
+
+func init() {
+	RegisterBuiltin("cli_app", cliApp)
+}
+
+/*
+cliApp creates a command line parser
+
+	@param name string - the program name shown in the usage message
+	@param description string (optional)
+*/
+func cliApp(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"App", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ, object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	description := ""
+	if len(args) == 2 {
+		description = args[1].(*object.String).Value
+	}
+	return object.NewCli(args[0].(*object.String).Value, description)
+}
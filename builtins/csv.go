@@ -0,0 +1,347 @@
+package builtins
+
+import (
+	"encoding/csv"
+	"errors"
+	"esolang/lang-esolang/object"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterBuiltin("csv_parse", csvParse)
+	RegisterRuntimeBuiltin("csv_read", csvRead)
+	RegisterRuntimeBuiltin("csv_each_row", csvEachRow)
+	RegisterBuiltin("csv_stringify", csvStringify)
+}
+
+// csvOptions are the settings accepted by the options hash of the csv builtins.
+type csvOptions struct {
+	delimiter  rune
+	comment    rune
+	header     bool
+	lazyQuotes bool
+	trimSpace  bool
+	quoteAll   bool
+	columns    []string
+}
+
+func parseCsvOptions(name string, args []object.Object, index int) (*csvOptions, object.Object) {
+	opts := &csvOptions{delimiter: ','}
+	if len(args) <= index {
+		return opts, nil
+	}
+	hash, ok := args[index].(*object.Hash)
+	if !ok {
+		return nil, newError("TypeError: %s() options must be a HASH, got %s", name, args[index].Type())
+	}
+
+	char := func(key string, value object.Object) (rune, object.Object) {
+		s, ok := value.(*object.String)
+		if !ok || utf8.RuneCountInString(s.Value) != 1 {
+			return 0, newError("TypeError: %s() option '%s' must be a single character", name, key)
+		}
+		r, _ := utf8.DecodeRuneInString(s.Value)
+		return r, nil
+	}
+	boolean := func(key string, value object.Object) (bool, object.Object) {
+		b, ok := value.(*object.Boolean)
+		if !ok {
+			return false, newError("TypeError: %s() option '%s' must be a BOOLEAN, got %s", name, key, value.Type())
+		}
+		return b.Value, nil
+	}
+
+	for _, pair := range hash.Pairs {
+		key, ok := pair.Key.(*object.String)
+		if !ok {
+			return nil, newError("TypeError: %s() option keys must be strings, got %s", name, pair.Key.Type())
+		}
+		var errObj object.Object
+		switch key.Value {
+		case "delimiter":
+			opts.delimiter, errObj = char(key.Value, pair.Value)
+		case "comment":
+			opts.comment, errObj = char(key.Value, pair.Value)
+		case "header":
+			opts.header, errObj = boolean(key.Value, pair.Value)
+		case "lazy_quotes":
+			opts.lazyQuotes, errObj = boolean(key.Value, pair.Value)
+		case "trim_space":
+			opts.trimSpace, errObj = boolean(key.Value, pair.Value)
+		case "quote_all":
+			opts.quoteAll, errObj = boolean(key.Value, pair.Value)
+		case "columns":
+			columns, ok := pair.Value.(*object.Array)
+			if !ok {
+				return nil, newError("TypeError: %s() option 'columns' must be an ARRAY, got %s", name, pair.Value.Type())
+			}
+			for _, column := range columns.Elements {
+				opts.columns = append(opts.columns, csvField(column))
+			}
+		default:
+			return nil, newError("ValueError: %s() unknown option '%s'", name, key.Value)
+		}
+		if errObj != nil {
+			return nil, errObj
+		}
+	}
+	if opts.delimiter == '"' || opts.delimiter == '\n' || opts.delimiter == '\r' || opts.delimiter == opts.comment {
+		return nil, newError("ValueError: %s() invalid delimiter %q", name, opts.delimiter)
+	}
+	return opts, nil
+}
+
+func (opts *csvOptions) reader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.delimiter
+	reader.Comment = opts.comment
+	reader.LazyQuotes = opts.lazyQuotes
+	reader.TrimLeadingSpace = opts.trimSpace
+	reader.ReuseRecord = true
+	return reader
+}
+
+// csvRows reads every record, calling fn with each row as an Array, or as a Hash
+// keyed by the header row when the header option is set. fn returns false to stop.
+func csvRows(r io.Reader, opts *csvOptions, fn func(row object.Object, number int64) (bool, object.Object)) object.Object {
+	reader := opts.reader(r)
+	var header []string
+	number := int64(0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return newError("CSVError: %s", err)
+		}
+
+		if opts.header && header == nil {
+			header = append([]string(nil), record...)
+			continue
+		}
+
+		var row object.Object
+		if header != nil {
+			pairs := make(map[string]object.Object, len(header))
+			for i, column := range header {
+				pairs[column] = &object.String{Value: record[i]}
+			}
+			row = newHash(pairs)
+		} else {
+			row = stringArray(record)
+		}
+		number++
+		more, errObj := fn(row, number)
+		if errObj != nil || !more {
+			return errObj
+		}
+	}
+}
+
+/*
+csvParse parses CSV text
+
+	@param text string
+	@param options Hash (optional) - delimiter, header, comment, lazy_quotes, trim_space
+	@return Array of Arrays of strings, or of Hashes when header is true
+	@exception CSVError: malformed input, reported with its line and column
+*/
+func csvParse(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Parse", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	opts, errObj := parseCsvOptions("Parse", args, 1)
+	if errObj != nil {
+		return errObj
+	}
+	return collectCsvRows(strings.NewReader(args[0].(*object.String).Value), opts)
+}
+
+func collectCsvRows(r io.Reader, opts *csvOptions) object.Object {
+	rows := &object.Array{Elements: []object.Object{}}
+	errObj := csvRows(r, opts, func(row object.Object, _ int64) (bool, object.Object) {
+		rows.Elements = append(rows.Elements, row)
+		return true, nil
+	})
+	if errObj != nil {
+		return errObj
+	}
+	return rows
+}
+
+// csvRead reads a whole CSV file with readFile and parses it like csvParse.
+func csvRead(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Read", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	opts, errObj := parseCsvOptions("Read", args, 1)
+	if errObj != nil {
+		return errObj
+	}
+	content := readFile(rt, args[0])
+	if content.Type() == object.ERROR_OBJ {
+		return content
+	}
+	return collectCsvRows(strings.NewReader(content.(*object.String).Value), opts)
+}
+
+/*
+csvEachRow streams a CSV file, calling fn with each row and its 1-based number
+
+	@param path string
+	@param fn function(row, number) - returning false stops the iteration
+	@param options Hash (optional) - the same options as csvParse
+	@return the number of rows read
+*/
+func csvEachRow(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"EachRow", args,
+		object.RangeOfArgs(2, 3),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	opts, errObj := parseCsvOptions("EachRow", args, 2)
+	if errObj != nil {
+		return errObj
+	}
+	file, err := rt.Open(args[0].(*object.String).Value)
+	if err != nil {
+		return object.FileError(err)
+	}
+	defer file.Close()
+
+	count := int64(0)
+	errObj = csvRows(file, opts, func(row object.Object, number int64) (bool, object.Object) {
+		count = number
+		result := object.CallFunction(args[1], row, &object.Integer{Value: number})
+		if result != nil && result.Type() == object.ERROR_OBJ {
+			return false, result
+		}
+		if b, ok := result.(*object.Boolean); ok && !b.Value {
+			return false, nil
+		}
+		return true, nil
+	})
+	if errObj != nil {
+		return errObj
+	}
+	return &object.Integer{Value: count}
+}
+
+/*
+csvStringify renders rows as CSV
+
+	@param rows Array of Arrays, or of Hashes which also produce a header row
+	@param options Hash (optional)
+		delimiter: the field separator, defaults to ","
+		quote_all: quote every field instead of only those that need it
+		columns: the header and column order for hashes, defaults to the sorted keys of all rows
+*/
+func csvStringify(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Stringify", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.ARRAY_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	opts, errObj := parseCsvOptions("Stringify", args, 1)
+	if errObj != nil {
+		return errObj
+	}
+
+	rows := args[0].(*object.Array).Elements
+	var records [][]string
+	columns := opts.columns
+	for i, row := range rows {
+		switch row := row.(type) {
+		case *object.Array:
+			record := make([]string, len(row.Elements))
+			for j, field := range row.Elements {
+				record[j] = csvField(field)
+			}
+			records = append(records, record)
+		case *object.Hash:
+			if columns == nil {
+				columns = csvColumns(rows)
+			}
+			record := make([]string, len(columns))
+			for j, column := range columns {
+				key := &object.String{Value: column}
+				if pair, ok := row.Pairs[key.HashKey()]; ok {
+					record[j] = csvField(pair.Value)
+				}
+			}
+			records = append(records, record)
+		default:
+			return newError("TypeError: Stringify() row %d must be an ARRAY or HASH, got %s", i, row.Type())
+		}
+	}
+	if columns != nil {
+		records = append([][]string{columns}, records...)
+	}
+
+	var out strings.Builder
+	for _, record := range records {
+		for j, field := range record {
+			if j > 0 {
+				out.WriteRune(opts.delimiter)
+			}
+			if opts.quoteAll || csvNeedsQuotes(field, opts.delimiter) {
+				field = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+			}
+			out.WriteString(field)
+		}
+		out.WriteString("\n")
+	}
+	return &object.String{Value: out.String()}
+}
+
+// csvColumns collects the sorted keys of every hash row.
+func csvColumns(rows []object.Object) []string {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, row := range rows {
+		hash, ok := row.(*object.Hash)
+		if !ok {
+			continue
+		}
+		for _, pair := range hash.Pairs {
+			column := csvField(pair.Key)
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func csvField(obj object.Object) string {
+	switch obj := obj.(type) {
+	case *object.String:
+		return obj.Value
+	case *object.Null:
+		return ""
+	}
+	return obj.Inspect()
+}
+
+func csvNeedsQuotes(field string, delimiter rune) bool {
+	return field != "" && (strings.ContainsRune(field, delimiter) || strings.ContainsAny(field, "\"\r\n") ||
+		field[0] == ' ' || field[0] == '\t' || field[len(field)-1] == ' ' || field[len(field)-1] == '\t')
+}
@@ -0,0 +1,111 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"fmt"
+	"math"
+	"strings"
+)
+
+func init() {
+	RegisterBuiltin("format", format)
+}
+
+/*
+format renders its arguments according to a printf style layout, e.g. format("%.2f", x)
+
+	@param layout string with Go fmt verbs (%d, %f, %s, %v, %q, %x, ...)
+	@param args the values substituted into the layout
+	@exception wrong number of arguments.
+	@exception wrong type of arguments.
+*/
+func format(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"format", args,
+		object.MinimumArgs(1),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+
+	layout := args[0].(*object.String).Value
+	verbs := formatVerbs(layout)
+	if len(verbs) != len(args)-1 {
+		return newError("ValueError: format() layout has %d verbs but got %d values", len(verbs), len(args)-1)
+	}
+	values := make([]interface{}, 0, len(args)-1)
+	for i, arg := range args[1:] {
+		value, ok := toFormatValue(verbs[i], arg)
+		if !ok {
+			return newError("ValueError: format() verb %%%c cannot format %s %s", verbs[i], arg.Type(), arg.Inspect())
+		}
+		values = append(values, value)
+	}
+	return &object.String{Value: fmt.Sprintf(layout, values...)}
+}
+
+// formatVerbs returns the verb of each value a layout formats, in order. %% formats none.
+func formatVerbs(layout string) []rune {
+	var verbs []rune
+	runes := []rune(layout)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			continue
+		}
+		// skip the flags, width and precision
+		i++
+		for i < len(runes) && strings.ContainsRune("+-# 0123456789.", runes[i]) {
+			i++
+		}
+		if i < len(runes) && runes[i] != '%' {
+			verbs = append(verbs, runes[i])
+		}
+	}
+	return verbs
+}
+
+// toFormatValue unwraps primitive objects so that fmt verbs such as %d and %.2f apply to
+// them, ok is false when verb cannot format obj. Floats without a fraction format as integers
+// and integers format as floats.
+func toFormatValue(verb rune, obj object.Object) (interface{}, bool) {
+	switch verb {
+	case 'd', 'c', 'U', 'o', 'O':
+		switch obj := obj.(type) {
+		case *object.Integer:
+			return obj.Value, true
+		case *object.BigInt:
+			return obj.Value, verb == 'd' || verb == 'o' || verb == 'O'
+		case *object.Float:
+			if obj.Value == math.Trunc(obj.Value) && math.Abs(obj.Value) < 1<<63 {
+				return int64(obj.Value), true
+			}
+		}
+		return nil, false
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		switch obj := obj.(type) {
+		case *object.Integer:
+			return float64(obj.Value), true
+		case *object.Float:
+			return obj.Value, true
+		}
+		return nil, false
+	case 't':
+		b, ok := obj.(*object.Boolean)
+		if !ok {
+			return nil, false
+		}
+		return b.Value, true
+	}
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return obj.Value, true
+	case *object.Float:
+		return obj.Value, true
+	case *object.String:
+		return obj.Value, true
+	case *object.Boolean:
+		return obj.Value, true
+	default:
+		return obj.Inspect(), true
+	}
+}
@@ -0,0 +1,384 @@
+package builtins
+
+import (
+	"bufio"
+	"errors"
+	"esolang/lang-esolang/object"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"syscall"
+)
+
+func init() {
+	RegisterRuntimeBuiltin("fs_exists", fsExists)
+	RegisterRuntimeBuiltin("fs_stat", fsStat)
+	RegisterRuntimeBuiltin("fs_list_dir", fsListDir)
+	RegisterRuntimeBuiltin("fs_walk", fsWalk)
+	RegisterRuntimeBuiltin("fs_glob", fsGlob)
+	RegisterRuntimeBuiltin("fs_mkdir_all", fsMkdirAll)
+	RegisterRuntimeBuiltin("fs_remove", fsRemove)
+	RegisterRuntimeBuiltin("fs_remove_all", fsRemoveAll)
+	RegisterRuntimeBuiltin("fs_rename", fsRename)
+	RegisterRuntimeBuiltin("fs_copy", fsCopy)
+	RegisterRuntimeBuiltin("fs_temp_dir", fsTempDir)
+	RegisterRuntimeBuiltin("fs_read_lines", fsReadLines)
+	RegisterRuntimeBuiltin("fs_each_line", fsEachLine)
+	RegisterRuntimeBuiltin("fs_open", fsOpen)
+}
+
+// pathArgs checks that exactly n string arguments were given and returns them.
+func pathArgs(name string, n int, args []object.Object) ([]string, object.Object) {
+	types := make([]object.ObjectType, n)
+	for i := range types {
+		types[i] = object.STRING_OBJ
+	}
+	if err := object.CheckTypings(
+		name, args,
+		object.ExactArgsLength(n),
+		object.WithTypes(types...),
+	); err != nil {
+		return nil, object.NewErrorFromTypings(err.Error())
+	}
+	paths := make([]string, n)
+	for i, arg := range args {
+		paths[i] = arg.(*object.String).Value
+	}
+	return paths, nil
+}
+
+func stringArray(values []string) *object.Array {
+	elements := make([]object.Object, len(values))
+	for i, v := range values {
+		elements[i] = &object.String{Value: v}
+	}
+	return &object.Array{Elements: elements}
+}
+
+func newHash(pairs map[string]object.Object) *object.Hash {
+	hash := &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
+	for k, v := range pairs {
+		key := &object.String{Value: k}
+		hash.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: v}
+	}
+	return hash
+}
+
+func fsExists(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Exists", 1, args)
+	if err != nil {
+		return err
+	}
+	_, statErr := rt.Stat(paths[0])
+	var denied *object.PermissionError
+	if errors.As(statErr, &denied) {
+		return object.FileError(statErr)
+	}
+	return &object.Boolean{Value: statErr == nil}
+}
+
+/*
+fsStat describes a file
+
+	@param path string
+	@return Hash with name, size, mode, mod_time (unix seconds), is_dir and is_file
+	@exception FileNotFoundError, PermissionError, IOError
+*/
+func fsStat(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Stat", 1, args)
+	if err != nil {
+		return err
+	}
+	info, statErr := rt.Stat(paths[0])
+	if statErr != nil {
+		return object.FileError(statErr)
+	}
+	return newHash(map[string]object.Object{
+		"name":     &object.String{Value: info.Name()},
+		"size":     &object.Integer{Value: info.Size()},
+		"mode":     &object.String{Value: info.Mode().String()},
+		"mod_time": &object.Integer{Value: info.ModTime().Unix()},
+		"is_dir":   &object.Boolean{Value: info.IsDir()},
+		"is_file":  &object.Boolean{Value: info.Mode().IsRegular()},
+	})
+}
+
+// fsListDir returns the sorted names of the entries in a directory.
+func fsListDir(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("ListDir", 1, args)
+	if err != nil {
+		return err
+	}
+	entries, readErr := rt.ReadDir(paths[0])
+	if readErr != nil {
+		return object.FileError(readErr)
+	}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return stringArray(names)
+}
+
+// fsWalk returns the path of every file and directory below root, in lexical order.
+func fsWalk(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Walk", 1, args)
+	if err != nil {
+		return err
+	}
+	var found []string
+	root := true
+	walkErr := rt.WalkDir(paths[0], func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !root {
+			found = append(found, path)
+		}
+		root = false
+		return nil
+	})
+	if walkErr != nil {
+		return object.FileError(walkErr)
+	}
+	return stringArray(found)
+}
+
+func fsGlob(rt *object.Runtime, args ...object.Object) object.Object {
+	patterns, err := pathArgs("Glob", 1, args)
+	if err != nil {
+		return err
+	}
+	matches, globErr := rt.Glob(patterns[0])
+	if globErr != nil {
+		return newError("ValueError: %s", globErr)
+	}
+	sort.Strings(matches)
+	return stringArray(matches)
+}
+
+func fsMkdirAll(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("MkdirAll", 1, args)
+	if err != nil {
+		return err
+	}
+	if writeErr := rt.CheckWrite("mkdir", paths[0]); writeErr != nil {
+		return object.FileError(writeErr)
+	}
+	if mkdirErr := os.MkdirAll(paths[0], 0755); mkdirErr != nil {
+		return object.FileError(mkdirErr)
+	}
+	return NULL
+}
+
+// fsRemove removes a file or an empty directory.
+func fsRemove(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Remove", 1, args)
+	if err != nil {
+		return err
+	}
+	if writeErr := rt.CheckWrite("remove", paths[0]); writeErr != nil {
+		return object.FileError(writeErr)
+	}
+	if removeErr := os.Remove(paths[0]); removeErr != nil {
+		return object.FileError(removeErr)
+	}
+	return NULL
+}
+
+// fsRemoveAll removes a path and everything it contains, a missing path is not an error.
+func fsRemoveAll(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("RemoveAll", 1, args)
+	if err != nil {
+		return err
+	}
+	if writeErr := rt.CheckWrite("remove", paths[0]); writeErr != nil {
+		return object.FileError(writeErr)
+	}
+	if removeErr := os.RemoveAll(paths[0]); removeErr != nil {
+		return object.FileError(removeErr)
+	}
+	return NULL
+}
+
+func fsRename(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Rename", 2, args)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if writeErr := rt.CheckWrite("rename", path); writeErr != nil {
+			return object.FileError(writeErr)
+		}
+	}
+	if renameErr := os.Rename(paths[0], paths[1]); renameErr != nil {
+		return object.FileError(renameErr)
+	}
+	return NULL
+}
+
+// fsCopy copies the contents and permissions of a file, overwriting the destination.
+func fsCopy(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("Copy", 2, args)
+	if err != nil {
+		return err
+	}
+	if readErr := rt.CheckRead(paths[0]); readErr != nil {
+		return object.FileError(readErr)
+	}
+	if writeErr := rt.CheckWrite("copy", paths[1]); writeErr != nil {
+		return object.FileError(writeErr)
+	}
+	if copyErr := copyFile(paths[0], paths[1]); copyErr != nil {
+		return object.FileError(copyErr)
+	}
+	return NULL
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return &fs.PathError{Op: "copy", Path: src, Err: syscall.EISDIR}
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// fsTempDir creates a new, uniquely named temporary directory and returns its path.
+func fsTempDir(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"TempDir", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if err := rt.CheckWrite("mkdir", os.TempDir()); err != nil {
+		return object.FileError(err)
+	}
+	dir, err := os.MkdirTemp("", "esolang-")
+	if err != nil {
+		return object.FileError(err)
+	}
+	return &object.String{Value: dir}
+}
+
+func fsReadLines(rt *object.Runtime, args ...object.Object) object.Object {
+	paths, err := pathArgs("ReadLines", 1, args)
+	if err != nil {
+		return err
+	}
+	file, openErr := rt.Open(paths[0])
+	if openErr != nil {
+		return object.FileError(openErr)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024*64)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		return object.FileError(scanErr)
+	}
+	return stringArray(lines)
+}
+
+/*
+fsEachLine streams a file, calling fn with each line and its 1-based line number
+
+	@param path string
+	@param fn function(line, number) - returning false stops the iteration
+	@exception FileNotFoundError, PermissionError, IOError
+*/
+func fsEachLine(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"EachLine", args,
+		object.ExactArgsLength(2),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	file, err := rt.Open(args[0].(*object.String).Value)
+	if err != nil {
+		return object.FileError(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024*64)
+	number := int64(0)
+	for scanner.Scan() {
+		number++
+		result := object.CallFunction(args[1], &object.String{Value: scanner.Text()}, &object.Integer{Value: number})
+		if result != nil && result.Type() == object.ERROR_OBJ {
+			return result
+		}
+		if b, ok := result.(*object.Boolean); ok && !b.Value {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return object.FileError(err)
+	}
+	return &object.Integer{Value: number}
+}
+
+var openFlags = map[string]int{
+	"r":  os.O_RDONLY,
+	"w":  os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
+	"a":  os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+	"r+": os.O_RDWR,
+	"w+": os.O_RDWR | os.O_CREATE | os.O_TRUNC,
+	"a+": os.O_RDWR | os.O_CREATE | os.O_APPEND,
+}
+
+/*
+fsOpen opens a file handle for streaming reads and writes
+
+	@param path string
+	@param mode string (optional) - one of r, w, a, r+, w+, a+ (defaults to r)
+	@exception FileNotFoundError, PermissionError, IOError
+*/
+func fsOpen(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Open", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ, object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	path := args[0].(*object.String).Value
+	mode := "r"
+	if len(args) == 2 {
+		mode = args[1].(*object.String).Value
+	}
+	flag, ok := openFlags[mode]
+	if !ok {
+		return newError("ValueError: invalid file mode '%s', expected one of r, w, a, r+, w+, a+", mode)
+	}
+	handle, err := rt.OpenFile(path, flag, 0644)
+	if err != nil {
+		return object.FileError(err)
+	}
+	return object.NewFile(path, mode, handle)
+}
@@ -1,15 +1,15 @@
 package builtins
 
 import (
-	"encoding/json"
 	"esolang/lang-esolang/object"
-	"fmt"
 	"io"
 	"net/http"
-	"reflect"
 	"strings"
 )
 
+// legacyClient is used by Http, which predates the eso/http request options.
+var legacyClient = &http.Client{Timeout: defaultHTTPTimeout}
+
 type Response struct {
 	Body       string
 	StatusCode int
@@ -18,16 +18,19 @@ type Response struct {
 }
 
 func _http(args ...object.Object) object.Object {
-	requestType := args[0].(*object.String).Value
 	if len(args) < 2 || len(args) > 3 {
 		return newError("Invalid Arguement got=%d, expected=3", len(args))
 	}
 	if args[0].Type() != object.STRING_OBJ {
-		if strings.ToUpper(requestType) != "GET" && strings.ToUpper(requestType) != "POST" && strings.ToUpper(requestType) != "PATCH" {
-			return newError("Request type can either be a `GET` or `POST` or `PATCH`")
-		}
 		return newError("Request type must be of type String, got %s", args[0].Type())
 	}
+	requestType := args[0].(*object.String).Value
+	if requestType != "GET" && requestType != "POST" && requestType != "PATCH" {
+		return newError("Request type can either be a `GET` or `POST` or `PATCH`")
+	}
+	if requestType != "GET" && len(args) != 3 {
+		return newError("%s request requires a body", requestType)
+	}
 
 	if args[1].Type() != object.STRING_OBJ {
 		return newError("URL must be of type String, got %s", args[1].Type())
@@ -38,7 +41,7 @@ func _http(args ...object.Object) object.Object {
 		if len(args) == 3 {
 			return newError("GET request should not have a body")
 		}
-		response, err := http.Get(url)
+		response, err := legacyClient.Get(url)
 		if err != nil {
 			return newError("HTTP Error: Error making GET request %s", url)
 		}
@@ -51,15 +54,14 @@ func _http(args ...object.Object) object.Object {
 		if outGoingBody.Type() != object.HASH_OBJ {
 			return newError("Body must be of type Hash, got %s", args[2].Type())
 		}
-		body := outGoingBody.(*object.Hash).Inspect()
-
-		response, err := http.Post(url, "application/json", strings.NewReader(body))
+		body, err := object.StringifyJSON(outGoingBody, "")
 		if err != nil {
-			return newError("HTTP Error: Error making POST request %s", url)
+			return newError("JSONError: %s", err)
 		}
 
+		response, err := legacyClient.Post(url, "application/json", strings.NewReader(body))
 		if err != nil {
-			return newError("HTTP Error: could read response body %s", err)
+			return newError("HTTP Error: Error making POST request %s", url)
 		}
 		return outputResp(response)
 	}
@@ -68,13 +70,15 @@ func _http(args ...object.Object) object.Object {
 		if outGoingBody.Type() != object.HASH_OBJ {
 			return newError("Body must be of type Hash, got %s", args[2].Type())
 		}
-		body := outGoingBody.(*object.Hash).Inspect()
-		client := &http.Client{}
+		body, err := object.StringifyJSON(outGoingBody, "")
+		if err != nil {
+			return newError("JSONError: %s", err)
+		}
 		req, err := http.NewRequest(requestType, url, strings.NewReader(body))
 		if err != nil {
 			return newError("HTTP Error: Error making PATCH request %s", url)
 		}
-		response, err := client.Do(req)
+		response, err := legacyClient.Do(req)
 
 		if err != nil {
 			return newError("HTTP Error: Error making PATCH request %s", url)
@@ -85,17 +89,11 @@ func _http(args ...object.Object) object.Object {
 	return NULL
 }
 
-func checkBrackets(text string) bool {
-	if len(text) < 2 {
-		return false // String is too short
-	}
-	return text[0] == '[' && text[len(text)-1] == ']'
-}
-
 func outputResp(res *http.Response) object.Object {
+	defer res.Body.Close()
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		newError("HTTP Error: could read response body %s", err)
+		return newError("HTTP Error: could read response body %s", err)
 	}
 	stringifiedBody := string(body)
 
@@ -134,60 +132,17 @@ func generateHashFromResponse(res Response) *object.Hash {
 	// populate bodyHash
 
 	bodyKey := (&object.String{Value: "Body"}).HashKey()
-	bodyHash := generateResponseBodyHash(res.Body)
+	bodyHash := generateResponseBody(res.Body)
 	bodyHashKey := object.HashKey{Type: object.STRING_OBJ, Value: bodyKey.Value}
 	hash.Pairs[bodyHashKey] = object.HashPair{Key: &object.String{Value: "Body"}, Value: bodyHash}
 
 	return hash
 }
 
-func generateResponseBodyHash(body string) *object.Hash {
-	// VERY MUCH WIP
-	hash := &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
-
-	if checkBrackets(body) {
-		// parse the string as a json array
-		var arr []interface{}
-		err := json.Unmarshal([]byte(body), &arr)
-		if err != nil {
-			fmt.Println("error parsing json object")
-			return &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
-		}
-		for i, v := range arr {
-			hashValueKey := (&object.Integer{Value: int64(i)}).HashKey()
-			hashKey := object.HashKey{Type: object.INTEGER_OBJ, Value: hashValueKey.Value}
-			hash.Pairs[hashKey] = object.HashPair{Key: &object.Integer{Value: int64(i)}, Value: &object.String{Value: fmt.Sprintf("%v", v)}}
-		}
-		// fmt.Println("value of hash arrayy ", hash.Inspect())
-		return hash
-	}
-
-	// parse the string as a json object
-	var obj map[string]interface{}
-	err := json.Unmarshal([]byte(body), &obj)
-	if err != nil {
-		fmt.Println("error parsing json object")
-		return &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
+// generateResponseBody decodes a JSON body into objects, any other body is returned as a string.
+func generateResponseBody(body string) object.Object {
+	if value, err := object.ParseJSON(body); err == nil {
+		return value
 	}
-	for k, v := range obj {
-		hashValueKey := (&object.String{Value: k}).HashKey()
-		hashKey := object.HashKey{Type: object.STRING_OBJ, Value: hashValueKey.Value}
-		// fmt.Println("value of k1 ", k)
-		// nested hash are currently converted to string - let fix this
-
-		if reflect.TypeOf(v).Kind() == reflect.Map {
-			for k1, v1 := range v.(map[string]interface{}) {
-				innerHash := &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
-				hashValueKey := (&object.String{Value: k1}).HashKey()
-				hashKey := object.HashKey{Type: object.STRING_OBJ, Value: hashValueKey.Value}
-				innerHash.Pairs[hashKey] = object.HashPair{Key: &object.String{Value: k1}, Value: &object.String{Value: fmt.Sprintf("%v", v1)}}
-				hash.Pairs[hashKey] = object.HashPair{Key: &object.String{Value: k}, Value: innerHash}
-
-			}
-		} else {
-			hash.Pairs[hashKey] = object.HashPair{Key: &object.String{Value: k}, Value: &object.String{Value: fmt.Sprintf("%v", v)}}
-		}
-	}
-	// fmt.Println("value of hash hashable ", hash.Inspect())
-	return hash
+	return &object.String{Value: body}
 }
@@ -0,0 +1,239 @@
+package builtins
+
+import (
+	"bytes"
+	"errors"
+	"esolang/lang-esolang/object"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout   = 30 * time.Second
+	defaultHTTPRedirects = 10
+)
+
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+func init() {
+	RegisterRuntimeBuiltin("http_request", requires((*object.Runtime).CheckNet, httpRequest))
+	for _, method := range httpMethods {
+		RegisterRuntimeBuiltin("http_"+strings.ToLower(method), requires((*object.Runtime).CheckNet, httpMethod(method)))
+	}
+}
+
+// requestOptions are the settings accepted by the options hash of a request.
+type requestOptions struct {
+	header    http.Header
+	query     url.Values
+	body      []byte
+	timeout   time.Duration
+	redirects int
+}
+
+/*
+httpRequest sends an HTTP request
+
+	@param method string - any HTTP method, e.g "GET" or "PROPFIND"
+	@param url string
+	@param options Hash (optional)
+		headers: hash of header names to strings
+		query: hash of parameters added to the url, an array value repeats the parameter
+		body: a string sent as is, or a hash or array sent as JSON
+		auth: [user, password] for basic auth or a token string for bearer auth
+		timeout: milliseconds before giving up, defaults to 30000
+		redirects: how many redirects to follow, defaults to 10, 0 returns the redirect response
+	@return Response
+	@exception TimeoutError, HTTPError
+*/
+func httpRequest(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Request", args,
+		object.RangeOfArgs(2, 3),
+		object.WithTypes(object.STRING_OBJ, object.STRING_OBJ, object.HASH_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	method := strings.ToUpper(args[0].(*object.String).Value)
+	return sendRequest(method, args[1:]...)
+}
+
+// httpMethod returns a builtin sending requests with the given method.
+func httpMethod(method string) object.BuiltinFunction {
+	name := strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+	return func(args ...object.Object) object.Object {
+		if err := object.CheckTypings(
+			name, args,
+			object.RangeOfArgs(1, 2),
+			object.WithTypes(object.STRING_OBJ, object.HASH_OBJ),
+		); err != nil {
+			return object.NewErrorFromTypings(err.Error())
+		}
+		return sendRequest(method, args...)
+	}
+}
+
+// sendRequest expects the url optionally followed by the options hash.
+func sendRequest(method string, args ...object.Object) object.Object {
+	opts := &requestOptions{
+		header:    http.Header{},
+		query:     url.Values{},
+		timeout:   defaultHTTPTimeout,
+		redirects: defaultHTTPRedirects,
+	}
+	if len(args) == 2 {
+		if errObj := parseRequestOptions(args[1].(*object.Hash), opts); errObj != nil {
+			return errObj
+		}
+	}
+
+	target, err := url.Parse(args[0].(*object.String).Value)
+	if err != nil {
+		return newError("ValueError: invalid url: %s", err)
+	}
+	if len(opts.query) > 0 {
+		query := target.Query()
+		for key, values := range opts.query {
+			query[key] = append(query[key], values...)
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	var body io.Reader
+	if opts.body != nil {
+		body = bytes.NewReader(opts.body)
+	}
+	req, err := http.NewRequest(method, target.String(), body)
+	if err != nil {
+		return newError("ValueError: %s", err)
+	}
+	for key, values := range opts.header {
+		req.Header[key] = values
+	}
+
+	client := &http.Client{
+		Timeout: opts.timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if opts.redirects == 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > opts.redirects {
+				return fmt.Errorf("stopped after %d redirects", opts.redirects)
+			}
+			return nil
+		},
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && urlErr.Timeout() {
+			return newError("TimeoutError: %s %s timed out after %s", method, target, opts.timeout)
+		}
+		return newError("HTTPError: %s", err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return newError("HTTPError: reading the body of %s %s: %s", method, target, err)
+	}
+	return &object.Response{
+		Method:     method,
+		URL:        res.Request.URL.String(),
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Header:     res.Header,
+		Body:       data,
+	}
+}
+
+func parseRequestOptions(hash *object.Hash, opts *requestOptions) object.Object {
+	for _, pair := range hash.Pairs {
+		key, ok := pair.Key.(*object.String)
+		if !ok {
+			return newError("TypeError: request option keys must be strings, got %s", pair.Key.Type())
+		}
+		switch key.Value {
+		case "headers":
+			headers, ok := pair.Value.(*object.Hash)
+			if !ok {
+				return newError("TypeError: request option 'headers' must be a HASH, got %s", pair.Value.Type())
+			}
+			for _, header := range headers.Pairs {
+				opts.header.Set(queryValue(header.Key), queryValue(header.Value))
+			}
+		case "query":
+			query, ok := pair.Value.(*object.Hash)
+			if !ok {
+				return newError("TypeError: request option 'query' must be a HASH, got %s", pair.Value.Type())
+			}
+			for _, param := range query.Pairs {
+				name := queryValue(param.Key)
+				if values, ok := param.Value.(*object.Array); ok {
+					for _, value := range values.Elements {
+						opts.query.Add(name, queryValue(value))
+					}
+					continue
+				}
+				opts.query.Add(name, queryValue(param.Value))
+			}
+		case "body":
+			switch body := pair.Value.(type) {
+			case *object.String:
+				opts.body = []byte(body.Value)
+			case *object.Hash, *object.Array:
+				text, err := object.StringifyJSON(body, "")
+				if err != nil {
+					return newError("JSONError: request body: %s", err)
+				}
+				opts.body = []byte(text)
+				if opts.header.Get("Content-Type") == "" {
+					opts.header.Set("Content-Type", "application/json")
+				}
+			default:
+				return newError("TypeError: request option 'body' must be a STRING, HASH or ARRAY, got %s", pair.Value.Type())
+			}
+		case "auth":
+			switch auth := pair.Value.(type) {
+			case *object.String:
+				opts.header.Set("Authorization", "Bearer "+auth.Value)
+			case *object.Array:
+				if len(auth.Elements) != 2 {
+					return newError("ValueError: request option 'auth' expects [user, password], got %d elements", len(auth.Elements))
+				}
+				req := &http.Request{Header: http.Header{}}
+				req.SetBasicAuth(queryValue(auth.Elements[0]), queryValue(auth.Elements[1]))
+				opts.header.Set("Authorization", req.Header.Get("Authorization"))
+			default:
+				return newError("TypeError: request option 'auth' must be a STRING token or an ARRAY [user, password], got %s", pair.Value.Type())
+			}
+		case "timeout":
+			ms, ok := pair.Value.(*object.Integer)
+			if !ok || ms.Value <= 0 {
+				return newError("TypeError: request option 'timeout' must be a positive INTEGER of milliseconds")
+			}
+			opts.timeout = time.Duration(ms.Value) * time.Millisecond
+		case "redirects":
+			n, ok := pair.Value.(*object.Integer)
+			if !ok || n.Value < 0 {
+				return newError("TypeError: request option 'redirects' must be a non-negative INTEGER")
+			}
+			opts.redirects = int(n.Value)
+		default:
+			return newError("ValueError: unknown request option '%s'", key.Value)
+		}
+	}
+	return nil
+}
+
+// queryValue renders headers and query parameters, strings are used without quotes.
+func queryValue(obj object.Object) string {
+	if s, ok := obj.(*object.String); ok {
+		return s.Value
+	}
+	return obj.Inspect()
+}
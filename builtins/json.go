@@ -0,0 +1,65 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"strings"
+)
+
+func init() {
+	RegisterBuiltin("json_parse", jsonParse)
+	RegisterBuiltin("json_stringify", jsonStringify)
+}
+
+/*
+jsonParse decodes a JSON document
+
+	@param text string
+	@return nested Hash, Array, Integer (BigInt when it does not fit), Float, String, Boolean and Null objects
+	@exception JSONError: the text is not valid JSON, reported with its line and column
+*/
+func jsonParse(args ...object.Object) object.Object {
+	texts, err := pathArgs("Parse", 1, args)
+	if err != nil {
+		return err
+	}
+	value, parseErr := object.ParseJSON(texts[0])
+	if parseErr != nil {
+		return newError("JSONError: %s", parseErr)
+	}
+	return value
+}
+
+/*
+jsonStringify encodes a value as JSON, hash keys are sorted
+
+	@param value
+	@param indent integer or string (optional) - pretty prints with this many spaces or this string
+	@exception JSONError: the value (or a nested one) cannot be represented in JSON, reported with its path
+*/
+func jsonStringify(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Stringify", args,
+		object.RangeOfArgs(1, 2),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	indent := ""
+	if len(args) == 2 {
+		switch arg := args[1].(type) {
+		case *object.Integer:
+			if arg.Value < 0 || arg.Value > 10 {
+				return newError("ValueError: Stringify indent must be between 0 and 10, got %d", arg.Value)
+			}
+			indent = strings.Repeat(" ", int(arg.Value))
+		case *object.String:
+			indent = arg.Value
+		default:
+			return newError("TypeError: Stringify indent must be an INTEGER or STRING, got %s", arg.Type())
+		}
+	}
+	text, err := object.StringifyJSON(args[0], indent)
+	if err != nil {
+		return newError("JSONError: %s", err)
+	}
+	return &object.String{Value: text}
+}
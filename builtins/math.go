@@ -0,0 +1,356 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+func init() {
+	RegisterBuiltin("math_sqrt", unaryMath("Sqrt", math.Sqrt))
+	RegisterBuiltin("math_log", unaryMath("Log", math.Log))
+	RegisterBuiltin("math_log2", unaryMath("Log2", math.Log2))
+	RegisterBuiltin("math_log10", unaryMath("Log10", math.Log10))
+	RegisterBuiltin("math_exp", unaryMath("Exp", math.Exp))
+	RegisterBuiltin("math_sin", unaryMath("Sin", math.Sin))
+	RegisterBuiltin("math_cos", unaryMath("Cos", math.Cos))
+	RegisterBuiltin("math_tan", unaryMath("Tan", math.Tan))
+	RegisterBuiltin("math_asin", unaryMath("Asin", math.Asin))
+	RegisterBuiltin("math_acos", unaryMath("Acos", math.Acos))
+	RegisterBuiltin("math_atan", unaryMath("Atan", math.Atan))
+	RegisterBuiltin("math_atan2", mathAtan2)
+	RegisterBuiltin("math_pow", mathPow)
+	RegisterBuiltin("math_inf", mathInf)
+	RegisterBuiltin("math_nan", mathNaN)
+	RegisterBuiltin("math_max", mathMax)
+	RegisterBuiltin("math_min", mathMin)
+	RegisterBuiltin("math_sum", mathSum)
+	RegisterBuiltin("math_mean", mathMean)
+	RegisterBuiltin("math_median", mathMedian)
+	RegisterBuiltin("math_stddev", mathStddev)
+	RegisterBuiltin("math_gcd", mathGcd)
+	RegisterBuiltin("math_lcm", mathLcm)
+	RegisterBuiltin("math_random", mathRandom)
+}
+
+// unaryMath wraps a float64 function from the math package as a builtin taking one number.
+func unaryMath(name string, fn func(float64) float64) object.BuiltinFunction {
+	return func(args ...object.Object) object.Object {
+		if err := object.CheckTypings(
+			name, args,
+			object.ExactArgsLength(1),
+		); err != nil {
+			return object.NewErrorFromTypings(err.Error())
+		}
+		x, ok := object.ToFloat(args[0])
+		if !ok {
+			return newError("TypeError: %s() expected a number got `%s`", name, args[0].Type())
+		}
+		return &object.Float{Value: fn(x)}
+	}
+}
+
+func mathAtan2(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Atan2", args,
+		object.ExactArgsLength(2),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	values, err := numberArgs("Atan2", args)
+	if err != nil {
+		return err
+	}
+	return &object.Float{Value: math.Atan2(values[0], values[1])}
+}
+
+/*
+mathPow raises base to the power of exponent
+
+	@param base number
+	@param exponent number
+	@return an Integer when both operands are integers, the exponent is non-negative
+	and the result fits, otherwise a Float
+*/
+func mathPow(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Pow", args,
+		object.ExactArgsLength(2),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	base, baseIsInt := args[0].(*object.Integer)
+	exponent, exponentIsInt := args[1].(*object.Integer)
+	if baseIsInt && exponentIsInt && exponent.Value >= 0 {
+		if result, ok := intPow(base.Value, exponent.Value); ok {
+			return &object.Integer{Value: result}
+		}
+	}
+	values, err := numberArgs("Pow", args)
+	if err != nil {
+		return err
+	}
+	return &object.Float{Value: math.Pow(values[0], values[1])}
+}
+
+// intPow computes base**exponent by repeated squaring, reporting false on overflow.
+func intPow(base, exponent int64) (int64, bool) {
+	result := int64(1)
+	for exponent > 0 {
+		if exponent&1 == 1 {
+			product := result * base
+			if base != 0 && product/base != result {
+				return 0, false
+			}
+			result = product
+		}
+		exponent >>= 1
+		if exponent > 0 {
+			square := base * base
+			if base != 0 && square/base != base {
+				return 0, false
+			}
+			base = square
+		}
+	}
+	return result, true
+}
+
+// mathInf returns positive infinity, or negative infinity when given a negative sign.
+func mathInf(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Inf", args,
+		object.RangeOfArgs(0, 1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	sign := 1
+	if len(args) == 1 && args[0].(*object.Integer).Value < 0 {
+		sign = -1
+	}
+	return &object.Float{Value: math.Inf(sign)}
+}
+
+func mathNaN(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"NaN", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return &object.Float{Value: math.NaN()}
+}
+
+// numberArgs converts every argument to a float64, failing on the first non number.
+func numberArgs(name string, args []object.Object) ([]float64, *object.Error) {
+	values := make([]float64, len(args))
+	for i, arg := range args {
+		value, ok := object.ToFloat(arg)
+		if !ok {
+			return nil, newError("TypeError: %s() expected argument #%d to be a number got `%s`", name, i+1, arg.Type())
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// numberList accepts either a single array of numbers or the numbers as separate arguments.
+func numberList(name string, args []object.Object) ([]object.Object, *object.Error) {
+	if len(args) == 1 {
+		if arr, ok := args[0].(*object.Array); ok {
+			args = arr.Elements
+		}
+	}
+	if len(args) == 0 {
+		return nil, newError("ValueError: %s() requires at least one number", name)
+	}
+	for i, arg := range args {
+		if arg.Type() != object.INTEGER_OBJ && arg.Type() != object.FLOAT_OBJ {
+			return nil, newError("TypeError: %s() expected element #%d to be a number got `%s`", name, i+1, arg.Type())
+		}
+	}
+	return args, nil
+}
+
+// extreme returns the element of args for which better(candidate, current) holds over all others.
+func extreme(name string, args []object.Object, better func(a, b float64) bool) object.Object {
+	numbers, err := numberList(name, args)
+	if err != nil {
+		return err
+	}
+	result := numbers[0]
+	current, _ := object.ToFloat(result)
+	for _, n := range numbers[1:] {
+		value, _ := object.ToFloat(n)
+		if better(value, current) {
+			result, current = n, value
+		}
+	}
+	return result
+}
+
+func mathMax(args ...object.Object) object.Object {
+	return extreme("Max", args, func(a, b float64) bool { return a > b })
+}
+
+func mathMin(args ...object.Object) object.Object {
+	return extreme("Min", args, func(a, b float64) bool { return a < b })
+}
+
+// mathSum adds up numbers, staying an Integer unless a Float is involved.
+func mathSum(args ...object.Object) object.Object {
+	if len(args) == 1 {
+		if arr, ok := args[0].(*object.Array); ok && len(arr.Elements) == 0 {
+			return &object.Integer{Value: 0}
+		}
+	}
+	numbers, err := numberList("Sum", args)
+	if err != nil {
+		return err
+	}
+	// integers are summed exactly, a sum past the Integer range is a BigInt
+	intSum := new(big.Int)
+	var floatSum float64
+	isFloat := false
+	for _, n := range numbers {
+		switch n := n.(type) {
+		case *object.Integer:
+			intSum.Add(intSum, big.NewInt(n.Value))
+		case *object.Float:
+			isFloat = true
+			floatSum += n.Value
+		}
+	}
+	if isFloat {
+		f, _ := new(big.Float).SetInt(intSum).Float64()
+		return &object.Float{Value: floatSum + f}
+	}
+	return object.NewBigInt(intSum)
+}
+
+func floatList(name string, args []object.Object) ([]float64, *object.Error) {
+	numbers, err := numberList(name, args)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, len(numbers))
+	for i, n := range numbers {
+		values[i], _ = object.ToFloat(n)
+	}
+	return values, nil
+}
+
+func mean(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
+
+func mathMean(args ...object.Object) object.Object {
+	values, err := floatList("Mean", args)
+	if err != nil {
+		return err
+	}
+	return &object.Float{Value: mean(values)}
+}
+
+func mathMedian(args ...object.Object) object.Object {
+	values, err := floatList("Median", args)
+	if err != nil {
+		return err
+	}
+	sort.Float64s(values)
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return &object.Float{Value: (values[middle-1] + values[middle]) / 2}
+	}
+	return &object.Float{Value: values[middle]}
+}
+
+// mathStddev returns the population standard deviation.
+func mathStddev(args ...object.Object) object.Object {
+	values, err := floatList("Stddev", args)
+	if err != nil {
+		return err
+	}
+	m := mean(values)
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - m) * (v - m)
+	}
+	return &object.Float{Value: math.Sqrt(variance / float64(len(values)))}
+}
+
+func integerList(name string, args []object.Object) ([]int64, *object.Error) {
+	if err := object.CheckTypings(
+		name, args,
+		object.MinimumArgs(1),
+	); err != nil {
+		return nil, newError(err.Error())
+	}
+	values := make([]int64, len(args))
+	for i, arg := range args {
+		n, ok := arg.(*object.Integer)
+		if !ok {
+			return nil, newError("TypeError: %s() expected argument #%d to be `%s` got `%s`", name, i+1, object.INTEGER_OBJ, arg.Type())
+		}
+		values[i] = n.Value
+	}
+	return values, nil
+}
+
+func mathGcd(args ...object.Object) object.Object {
+	values, err := integerList("Gcd", args)
+	if err != nil {
+		return err
+	}
+	// computed as BigInts, the gcd of the smallest Integer and 0 does not fit one
+	result := new(big.Int)
+	for _, v := range values {
+		result.GCD(nil, nil, result, big.NewInt(v))
+	}
+	return object.NewBigInt(result)
+}
+
+func mathLcm(args ...object.Object) object.Object {
+	values, err := integerList("Lcm", args)
+	if err != nil {
+		return err
+	}
+	result := new(big.Int).Abs(big.NewInt(values[0]))
+	for _, v := range values[1:] {
+		n := new(big.Int).Abs(big.NewInt(v))
+		if result.Sign() == 0 || n.Sign() == 0 {
+			result.SetInt64(0)
+			continue
+		}
+		result.Mul(result.Quo(result, new(big.Int).GCD(nil, nil, result, n)), n)
+	}
+	return object.NewBigInt(result)
+}
+
+/*
+mathRandom creates a seedable random number generator
+
+	@param seed integer (optional) - defaults to the current time
+	@exception wrong type of arguments.
+*/
+func mathRandom(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Random", args,
+		object.RangeOfArgs(0, 1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	seed := time.Now().UnixNano()
+	if len(args) == 1 {
+		seed = args[0].(*object.Integer).Value
+	}
+	return object.NewRandom(seed)
+}
@@ -6,22 +6,22 @@ import (
 	"strings"
 )
 
-func Print(args ...object.Object) object.Object {
+func Print(rt *object.Runtime, args ...object.Object) object.Object {
 	toBePrinted := []string{}
 	for _, arg := range args {
-		fmt.Print(arg.Inspect())
+		fmt.Fprint(rt.Stdout, arg.Inspect())
 		toBePrinted = append(toBePrinted, arg.Inspect())
 	}
-	return &object.String{Value: strings.Join(toBePrinted, " ") + "flag=noshow"}
+	return &object.String{Value: strings.Join(toBePrinted, " "), Printed: true}
 }
 
-func Println(args ...object.Object) object.Object {
+func Println(rt *object.Runtime, args ...object.Object) object.Object {
 	toBePrinted := []string{}
 	for _, arg := range args {
 		toBePrinted = append(toBePrinted, arg.Inspect())
-		fmt.Println(arg.Inspect())
+		fmt.Fprintln(rt.Stdout, arg.Inspect())
 	}
-	return &object.String{Value: strings.Join(toBePrinted, "\n") + "flag=noshow"}
+	return &object.String{Value: strings.Join(toBePrinted, "\n"), Printed: true}
 }
 
 // TODO: Rethink how read should work
@@ -0,0 +1,392 @@
+package builtins
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"esolang/lang-esolang/object"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterRuntimeBuiltin("process_args", processArgs)
+	RegisterRuntimeBuiltin("process_env", requires((*object.Runtime).CheckEnv, processEnv))
+	RegisterRuntimeBuiltin("process_getenv", requires((*object.Runtime).CheckEnv, processGetenv))
+	RegisterRuntimeBuiltin("process_setenv", requires((*object.Runtime).CheckEnv, processSetenv))
+	RegisterRuntimeBuiltin("process_unsetenv", requires((*object.Runtime).CheckEnv, processUnsetenv))
+	RegisterRuntimeBuiltin("process_cwd", requires((*object.Runtime).CheckEnv, processCwd))
+	RegisterRuntimeBuiltin("process_chdir", requires((*object.Runtime).CheckRun, processChdir))
+	RegisterRuntimeBuiltin("process_pid", requires((*object.Runtime).CheckEnv, processPid))
+	RegisterRuntimeBuiltin("process_exit", requires((*object.Runtime).CheckRun, processExit))
+	RegisterRuntimeBuiltin("process_spawn", processSpawn)
+	RegisterRuntimeBuiltin("process_raise_on_failure", processRaiseOnFailure)
+}
+
+func processArgs(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Args", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return stringArray(rt.Args)
+}
+
+// processEnv returns every environment variable as a hash.
+func processEnv(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Env", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	pairs := make(map[string]object.Object)
+	for _, entry := range os.Environ() {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			pairs[key] = &object.String{Value: value}
+		}
+	}
+	return newHash(pairs)
+}
+
+/*
+processGetenv reads an environment variable
+
+	@param name string
+	@param default (optional) - returned when the variable is not set, defaults to null
+*/
+func processGetenv(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"GetEnv", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if value, ok := os.LookupEnv(args[0].(*object.String).Value); ok {
+		return &object.String{Value: value}
+	}
+	if len(args) == 2 {
+		return args[1]
+	}
+	return NULL
+}
+
+func processSetenv(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"SetEnv", args,
+		object.ExactArgsLength(2),
+		object.WithTypes(object.STRING_OBJ, object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if err := os.Setenv(args[0].(*object.String).Value, args[1].(*object.String).Value); err != nil {
+		return newError("ValueError: %s", err)
+	}
+	return NULL
+}
+
+func processUnsetenv(args ...object.Object) object.Object {
+	names, err := pathArgs("UnsetEnv", 1, args)
+	if err != nil {
+		return err
+	}
+	if unsetErr := os.Unsetenv(names[0]); unsetErr != nil {
+		return newError("ValueError: %s", unsetErr)
+	}
+	return NULL
+}
+
+func processCwd(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Cwd", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return object.FileError(err)
+	}
+	return &object.String{Value: dir}
+}
+
+func processChdir(args ...object.Object) object.Object {
+	paths, err := pathArgs("Chdir", 1, args)
+	if err != nil {
+		return err
+	}
+	if chdirErr := os.Chdir(paths[0]); chdirErr != nil {
+		return object.FileError(chdirErr)
+	}
+	return NULL
+}
+
+func processPid(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Pid", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return &object.Integer{Value: int64(os.Getpid())}
+}
+
+/*
+processExit stops the script, the error it returns unwinds the evaluation and
+the CLI exits with code once it reaches the top
+
+	@param code integer (optional) - the exit status, defaults to 0
+*/
+func processExit(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Exit", args,
+		object.RangeOfArgs(0, 1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	code := 0
+	if len(args) == 1 {
+		code = int(args[0].(*object.Integer).Value)
+	}
+	return object.NewExit(code)
+}
+
+/*
+processRaiseOnFailure controls whether a backtick command exiting with a non-zero status is an error
+
+	@param enabled boolean
+	@return the previous setting
+*/
+func processRaiseOnFailure(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"RaiseOnFailure", args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.BOOLEAN_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	previous := rt.RaiseOnCommandFailure.Swap(args[0].(*object.Boolean).Value)
+	return &object.Boolean{Value: previous}
+}
+
+// spawnOptions are the settings accepted by the options hash of Spawn.
+type spawnOptions struct {
+	stdin    *string
+	env      []string
+	cwd      string
+	timeout  time.Duration
+	onStdout object.Object
+	onStderr object.Object
+}
+
+func parseSpawnOptions(hash *object.Hash) (*spawnOptions, object.Object) {
+	opts := &spawnOptions{}
+	for _, pair := range hash.Pairs {
+		key, ok := pair.Key.(*object.String)
+		if !ok {
+			return nil, newError("TypeError: Spawn option keys must be strings, got %s", pair.Key.Type())
+		}
+		switch key.Value {
+		case "stdin":
+			s, ok := pair.Value.(*object.String)
+			if !ok {
+				return nil, newError("TypeError: Spawn option 'stdin' must be a STRING, got %s", pair.Value.Type())
+			}
+			opts.stdin = &s.Value
+		case "cwd":
+			s, ok := pair.Value.(*object.String)
+			if !ok {
+				return nil, newError("TypeError: Spawn option 'cwd' must be a STRING, got %s", pair.Value.Type())
+			}
+			opts.cwd = s.Value
+		case "timeout":
+			ms, ok := pair.Value.(*object.Integer)
+			if !ok || ms.Value < 0 {
+				return nil, newError("TypeError: Spawn option 'timeout' must be a non-negative INTEGER of milliseconds")
+			}
+			opts.timeout = time.Duration(ms.Value) * time.Millisecond
+		case "env":
+			env, ok := pair.Value.(*object.Hash)
+			if !ok {
+				return nil, newError("TypeError: Spawn option 'env' must be a HASH, got %s", pair.Value.Type())
+			}
+			for _, variable := range env.Pairs {
+				name, nameOk := variable.Key.(*object.String)
+				value, valueOk := variable.Value.(*object.String)
+				if !nameOk || !valueOk {
+					return nil, newError("TypeError: Spawn option 'env' must map STRING names to STRING values")
+				}
+				opts.env = append(opts.env, name.Value+"="+value.Value)
+			}
+			// map iteration order is random, keep the environment deterministic
+			sort.Strings(opts.env)
+		case "on_stdout":
+			opts.onStdout = pair.Value
+		case "on_stderr":
+			opts.onStderr = pair.Value
+		default:
+			return nil, newError("ValueError: unknown Spawn option '%s'", key.Value)
+		}
+	}
+	return opts, nil
+}
+
+/*
+processSpawn runs a program and waits for it to finish
+
+	@param command Array of strings - the program followed by its arguments, no shell is involved
+	@param options Hash (optional)
+		stdin: string piped to the program
+		env: hash of variables added to (or overriding) the current environment
+		cwd: working directory of the program
+		timeout: milliseconds after which the program is killed
+		on_stdout: function called with each line of stdout as it is produced
+		on_stderr: function called with each line of stderr as it is produced
+	@return Hash with stdout, stderr, exitCode and timedOut, streamed output is not collected
+	@exception ProcessError: the program could not be started
+*/
+func processSpawn(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := rt.CheckRun(); err != nil {
+		return newError("PermissionError: %s", err)
+	}
+	if err := object.CheckTypings(
+		"Spawn", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.ARRAY_OBJ, object.HASH_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+
+	elements := args[0].(*object.Array).Elements
+	if len(elements) == 0 {
+		return newError("ValueError: Spawn expects a non-empty command")
+	}
+	command := make([]string, len(elements))
+	for i, element := range elements {
+		s, ok := element.(*object.String)
+		if !ok {
+			return newError("TypeError: Spawn command must only contain strings, got %s", element.Type())
+		}
+		command[i] = s.Value
+	}
+
+	opts := &spawnOptions{}
+	if len(args) == 2 {
+		var errObj object.Object
+		if opts, errObj = parseSpawnOptions(args[1].(*object.Hash)); errObj != nil {
+			return errObj
+		}
+	}
+
+	// the child is killed when the evaluation stops too
+	ctx := rt.Context()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = opts.cwd
+	if opts.env != nil {
+		// later entries win, so the overrides replace inherited values
+		cmd.Env = append(os.Environ(), opts.env...)
+	}
+	if opts.stdin != nil {
+		cmd.Stdin = strings.NewReader(*opts.stdin)
+	}
+
+	var stdout, stderr bytes.Buffer
+	lines := make(chan streamedLine)
+	streams := 0
+	if opts.onStdout != nil {
+		pipe, err := cmd.StdoutPipe()
+		if err != nil {
+			return newError("ProcessError: %s", err)
+		}
+		go streamLines(pipe, opts.onStdout, lines)
+		streams++
+	} else {
+		cmd.Stdout = &stdout
+	}
+	if opts.onStderr != nil {
+		pipe, err := cmd.StderrPipe()
+		if err != nil {
+			return newError("ProcessError: %s", err)
+		}
+		go streamLines(pipe, opts.onStderr, lines)
+		streams++
+	} else {
+		cmd.Stderr = &stderr
+	}
+
+	if err := cmd.Start(); err != nil {
+		return newError("ProcessError: failed to start '%s': %s", command[0], err)
+	}
+
+	// callbacks run on this goroutine, the readers only hand lines over
+	var callbackErr object.Object
+	for streams > 0 {
+		line := <-lines
+		if line.done {
+			streams--
+			continue
+		}
+		if callbackErr != nil {
+			continue
+		}
+		result := object.CallFunction(line.callback, &object.String{Value: line.text})
+		if result != nil && result.Type() == object.ERROR_OBJ {
+			callbackErr = result
+			_ = cmd.Process.Kill()
+		}
+	}
+
+	waitErr := cmd.Wait()
+	if callbackErr != nil {
+		return callbackErr
+	}
+	if err := rt.Context().Err(); err != nil {
+		return object.ContextError(err)
+	}
+
+	exitCode := int64(0)
+	timedOut := ctx.Err() == context.DeadlineExceeded
+	if waitErr != nil {
+		var exitError *exec.ExitError
+		if !errors.As(waitErr, &exitError) {
+			return newError("ProcessError: %s", waitErr)
+		}
+		exitCode = int64(exitError.ExitCode())
+	}
+
+	return newHash(map[string]object.Object{
+		"stdout":   &object.String{Value: stdout.String()},
+		"stderr":   &object.String{Value: stderr.String()},
+		"exitCode": &object.Integer{Value: exitCode},
+		"timedOut": &object.Boolean{Value: timedOut},
+	})
+}
+
+type streamedLine struct {
+	callback object.Object
+	text     string
+	done     bool
+}
+
+func streamLines(r io.Reader, callback object.Object, lines chan<- streamedLine) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024*64)
+	for scanner.Scan() {
+		lines <- streamedLine{callback: callback, text: scanner.Text()}
+	}
+	lines <- streamedLine{done: true}
+}
@@ -1,8 +1,9 @@
 package builtins
 
 import (
+	"errors"
 	"esolang/lang-esolang/object"
-	"os"
+	"io/fs"
 )
 
 /*
@@ -13,7 +14,7 @@ readFile reads a file and returns the content of the file as a string
 	@exception wrong type of arguments.
 	@exception I/O Error: possible errors reading file
 */
-func readFile(args ...object.Object) object.Object {
+func readFile(rt *object.Runtime, args ...object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("Invalid Arguement got=%d, expected=1", len(args))
 	}
@@ -23,10 +24,13 @@ func readFile(args ...object.Object) object.Object {
 	}
 
 	file := args[0].(*object.String).Value
-	inputFile, err := os.ReadFile(file)
+	inputFile, err := rt.ReadFile(file)
+	if errors.Is(err, fs.ErrPermission) {
+		return object.FileError(err)
+	}
 
 	// check if file exists
-	if _, err := os.Stat(file); os.IsNotExist(err) {
+	if _, err := rt.Stat(file); errors.Is(err, fs.ErrNotExist) {
 		return newError("I/O Error: No such file %s", file)
 	}
 
@@ -0,0 +1,55 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"regexp"
+)
+
+func init() {
+	RegisterBuiltin("regex_compile", regexCompile)
+	RegisterBuiltin("regex_escape", regexEscape)
+}
+
+/*
+regexCompile compiles a pattern into a Regex object, a Regex is returned unchanged
+
+	@param pattern string or Regex
+	@exception wrong number of arguments.
+	@exception wrong type of arguments.
+	@exception RegexError: the pattern is not a valid regular expression
+*/
+func regexCompile(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"regex_compile", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if re, ok := args[0].(*object.Regex); ok {
+		return re
+	}
+	if err := object.CheckTypings(
+		"regex_compile", args,
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+
+	re, err := regexp.Compile(args[0].(*object.String).Value)
+	if err != nil {
+		return newError("RegexError: %s", err)
+	}
+	return &object.Regex{Value: re}
+}
+
+// regexEscape escapes all regular expression metacharacters in the given string.
+func regexEscape(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"regex_escape", args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return &object.String{Value: regexp.QuoteMeta(args[0].(*object.String).Value)}
+}
@@ -0,0 +1,194 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+func init() {
+	RegisterRuntimeBuiltin("server_new", serverNew)
+	RegisterBuiltin("server_json", serverJson)
+	RegisterBuiltin("server_text", serverText)
+	RegisterBuiltin("server_status", serverStatus)
+}
+
+func serverNew(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"New", args,
+		object.ExactArgsLength(0),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if err := rt.CheckNet(); err != nil {
+		return newError("PermissionError: %s", err)
+	}
+	return &object.Server{Router: newEchoRouter(rt.Stderr)}
+}
+
+// echoRouter serves the routes of a server with echo. Handlers run one at a
+// time because the interpreter is not safe for concurrent use.
+type echoRouter struct {
+	echo *echo.Echo
+	mu   sync.Mutex
+	// log receives the errors raised by handlers
+	log io.Writer
+}
+
+func newEchoRouter(log io.Writer) *echoRouter {
+	e := echo.New()
+	e.HideBanner = true
+	return &echoRouter{echo: e, log: log}
+}
+
+func (r *echoRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.echo.ServeHTTP(w, req)
+}
+
+func (r *echoRouter) Route(method, path string, handler object.Object) {
+	if method == "ANY" {
+		r.echo.Any(path, r.handle(handler))
+		return
+	}
+	r.echo.Add(method, path, r.handle(handler))
+}
+
+func (r *echoRouter) Routes() int {
+	return len(r.echo.Routes())
+}
+
+func (r *echoRouter) Listen(port int) error {
+	if err := r.echo.Start(fmt.Sprintf(":%d", port)); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// handle adapts an esolang function to an echo handler.
+func (r *echoRouter) handle(handler object.Object) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		params := make(map[string]string)
+		for _, name := range c.ParamNames() {
+			params[name] = c.Param(name)
+		}
+		req := &object.Request{Request: c.Request(), Params: params, Body: body}
+
+		r.mu.Lock()
+		result := object.CallFunction(handler, req)
+		r.mu.Unlock()
+
+		return writeReply(c, result, r.log)
+	}
+}
+
+// writeReply sends what a handler returned: a Response built by the reply helpers,
+// a string as text, null as 204 and anything else as JSON. Errors are written to log.
+func writeReply(c echo.Context, result object.Object, log io.Writer) error {
+	switch r := result.(type) {
+	case nil, *object.Null:
+		return c.NoContent(http.StatusNoContent)
+	case *object.Error:
+		fmt.Fprintf(log, "%s %s: %s\n", c.Request().Method, c.Request().URL.Path, r.Message)
+		return c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	case *object.Response:
+		for key, values := range r.Header {
+			for _, value := range values {
+				c.Response().Header().Add(key, value)
+			}
+		}
+		c.Response().WriteHeader(r.StatusCode)
+		_, err := c.Response().Write(r.Body)
+		return err
+	case *object.String:
+		return c.String(http.StatusOK, r.Value)
+	}
+	text, err := object.StringifyJSON(result, "")
+	if err != nil {
+		return writeReply(c, newError("JSONError: %s", err), log)
+	}
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, []byte(text))
+}
+
+// replyStatus reads the optional status code argument of the reply helpers.
+func replyStatus(name string, args []object.Object, index int) (int, object.Object) {
+	if len(args) <= index {
+		return http.StatusOK, nil
+	}
+	code := args[index].(*object.Integer).Value
+	if code < 100 || code > 999 {
+		return 0, newError("ValueError: %s status must be between 100 and 999, got %d", name, code)
+	}
+	return int(code), nil
+}
+
+/*
+serverJson builds a JSON response for a server handler
+
+	@param value - any value JSON can represent
+	@param status integer (optional) - defaults to 200
+*/
+func serverJson(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Json", args,
+		object.RangeOfArgs(1, 2),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if len(args) == 2 && args[1].Type() != object.INTEGER_OBJ {
+		return newError("TypeError: Json() expected argument #2 to be `INTEGER` got `%s`", args[1].Type())
+	}
+	status, errObj := replyStatus("Json", args, 1)
+	if errObj != nil {
+		return errObj
+	}
+	text, err := object.StringifyJSON(args[0], "")
+	if err != nil {
+		return newError("JSONError: %s", err)
+	}
+	return object.NewReply(status, "application/json; charset=UTF-8", []byte(text))
+}
+
+/*
+serverText builds a plain text response for a server handler
+
+	@param text string
+	@param status integer (optional) - defaults to 200
+*/
+func serverText(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Text", args,
+		object.RangeOfArgs(1, 2),
+		object.WithTypes(object.STRING_OBJ, object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	status, errObj := replyStatus("Text", args, 1)
+	if errObj != nil {
+		return errObj
+	}
+	return object.NewReply(status, "text/plain; charset=UTF-8", []byte(args[0].(*object.String).Value))
+}
+
+// serverStatus builds an empty response with the given status code.
+func serverStatus(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Status", args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	status, errObj := replyStatus("Status", args, 0)
+	if errObj != nil {
+		return errObj
+	}
+	return object.NewReply(status, "", nil)
+}
@@ -26,6 +26,33 @@ var Os string
 //go:embed stdlib/http.eso
 var Http string
 
+//go:embed stdlib/regex.eso
+var Regex string
+
+//go:embed stdlib/fs.eso
+var Fs string
+
+//go:embed stdlib/process.eso
+var Process string
+
+//go:embed stdlib/cli.eso
+var Cli string
+
+//go:embed stdlib/json.eso
+var Json string
+
+//go:embed stdlib/server.eso
+var Server string
+
+//go:embed stdlib/csv.eso
+var Csv string
+
+//go:embed stdlib/time.eso
+var Time string
+
+//go:embed stdlib/async.eso
+var Async string
+
 func getAllStdLib() string {
 	return ArrayUtils + "\n" + BoolUtils + "\n" + StringUtils + "\n" + SetUtils
 }
@@ -46,6 +73,24 @@ func GetStdLib(lib string) (string, error) {
 		return Os, nil
 	case "http":
 		return Http, nil
+	case "regex":
+		return Regex, nil
+	case "fs":
+		return Fs, nil
+	case "process":
+		return Process, nil
+	case "cli":
+		return Cli, nil
+	case "json":
+		return Json, nil
+	case "server":
+		return Server, nil
+	case "csv":
+		return Csv, nil
+	case "time":
+		return Time, nil
+	case "async":
+		return Async, nil
 	default:
 		return "", fmt.Errorf("stdlib: %s not found", lib)
 	}
@@ -0,0 +1,181 @@
+package builtins
+
+import (
+	"esolang/lang-esolang/object"
+	"math"
+	"time"
+)
+
+func init() {
+	RegisterBuiltin("time_now", timeNow)
+	RegisterBuiltin("time_parse", timeParse)
+	RegisterBuiltin("time_date", timeDate)
+	RegisterBuiltin("time_unix", timeUnix)
+	RegisterBuiltin("time_unix_milli", timeUnixMilli)
+	RegisterBuiltin("time_duration", timeDuration)
+	RegisterBuiltin("time_since", timeSince)
+	RegisterRuntimeBuiltin("time_sleep", timeSleep)
+}
+
+// optionalLocation reads the zone argument at index, defaulting to fallback.
+func optionalLocation(args []object.Object, index int, fallback *time.Location) (*time.Location, object.Object) {
+	if len(args) <= index {
+		return fallback, nil
+	}
+	return object.LoadLocation(args[index].(*object.String).Value)
+}
+
+// timeNow returns the current time, in the local zone unless one is given.
+func timeNow(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Now", args,
+		object.RangeOfArgs(0, 1),
+		object.WithTypes(object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	location, errObj := optionalLocation(args, 0, time.Local)
+	if errObj != nil {
+		return errObj
+	}
+	return &object.DateTime{Value: time.Now().In(location)}
+}
+
+/*
+timeParse reads a date from text
+
+	@param text string
+	@param layout string (optional) - a named layout or a Go reference layout, defaults to ISO (RFC 3339)
+	@param zone string (optional) - used when the text has no offset, defaults to UTC
+	@exception ValueError: the text does not match the layout
+*/
+func timeParse(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Parse", args,
+		object.RangeOfArgs(1, 3),
+		object.WithTypes(object.STRING_OBJ, object.STRING_OBJ, object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	layout := "ISO"
+	if len(args) > 1 {
+		layout = args[1].(*object.String).Value
+	}
+	location, errObj := optionalLocation(args, 2, time.UTC)
+	if errObj != nil {
+		return errObj
+	}
+	dt, errObj := object.ParseDateTime(args[0].(*object.String).Value, layout, location)
+	if errObj != nil {
+		return errObj
+	}
+	return dt
+}
+
+/*
+timeDate builds a date from its parts, out of range values are normalized
+
+	@param year, month, day integer
+	@param hour, minute, second integer (optional)
+	@param zone string (optional) - defaults to UTC
+*/
+func timeDate(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Date", args,
+		object.RangeOfArgs(3, 7),
+		object.WithTypes(object.INTEGER_OBJ, object.INTEGER_OBJ, object.INTEGER_OBJ,
+			object.INTEGER_OBJ, object.INTEGER_OBJ, object.INTEGER_OBJ, object.STRING_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	if len(args) == 4 || len(args) == 5 {
+		return newError("TypeError: Date() expects hour, minute and second together")
+	}
+	parts := [6]int{}
+	for i := 0; i < len(args) && i < 6; i++ {
+		parts[i] = int(args[i].(*object.Integer).Value)
+	}
+	location, errObj := optionalLocation(args, 6, time.UTC)
+	if errObj != nil {
+		return errObj
+	}
+	return &object.DateTime{Value: time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], 0, location)}
+}
+
+// timeUnix converts seconds since the Unix epoch, fractions are kept, to a UTC DateTime.
+func timeUnix(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Unix", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	switch arg := args[0].(type) {
+	case *object.Integer:
+		return &object.DateTime{Value: time.Unix(arg.Value, 0).UTC()}
+	case *object.Float:
+		seconds, fraction := math.Modf(arg.Value)
+		return &object.DateTime{Value: time.Unix(int64(seconds), int64(math.Round(fraction*1e9))).UTC()}
+	}
+	return newError("TypeError: Unix() expected argument #1 to be `INTEGER` or `FLOAT` got `%s`", args[0].Type())
+}
+
+func timeUnixMilli(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"UnixMilli", args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.INTEGER_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return &object.DateTime{Value: time.UnixMilli(args[0].(*object.Integer).Value).UTC()}
+}
+
+// timeDuration builds a Duration from milliseconds or a string such as "1h30m".
+func timeDuration(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Duration", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	d, errObj := object.ToDuration(args[0])
+	if errObj != nil {
+		return errObj
+	}
+	return &object.Duration{Value: d}
+}
+
+func timeSince(args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Since", args,
+		object.ExactArgsLength(1),
+		object.WithTypes(object.DATETIME_OBJ),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	return &object.Duration{Value: time.Since(args[0].(*object.DateTime).Value)}
+}
+
+// timeSleep pauses the script for a number of milliseconds or a Duration, it
+// returns early with the evaluation's error when its context is done.
+func timeSleep(rt *object.Runtime, args ...object.Object) object.Object {
+	if err := object.CheckTypings(
+		"Sleep", args,
+		object.ExactArgsLength(1),
+	); err != nil {
+		return object.NewErrorFromTypings(err.Error())
+	}
+	d, errObj := object.ToDuration(args[0])
+	if errObj != nil {
+		return errObj
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return NULL
+	case <-rt.Done():
+		return object.ContextError(rt.Context().Err())
+	}
+}
@@ -13,7 +13,7 @@ writeFile writes data to a named file, creating it if necessary
 	@exception wrong type of arguments.
 	@exception I/O Error: possible error writing to file
 */
-func writeFile(arg ...object.Object) object.Object {
+func writeFile(rt *object.Runtime, arg ...object.Object) object.Object {
 	if len(arg) < 2 || len(arg) > 3 {
 		return newError("Invalid Arguement got=%d, expected=3", len(arg))
 	}
@@ -26,6 +26,10 @@ func writeFile(arg ...object.Object) object.Object {
 		return newError("Supplied content must be of type String, got %s", arg[1].Type())
 	}
 
+	if err := rt.CheckWrite("write", arg[0].(*object.String).Value); err != nil {
+		return object.FileError(err)
+	}
+
 	// check for flag - append or prepend
 	if len(arg) == 3 {
 		if arg[2].Type() != object.STRING_OBJ {
@@ -83,6 +87,8 @@ func prependToFile(filePath string, content string) object.Object {
 	newData := []byte(content + "\n")
 	newData = append(newData, existingData...)
 
-	err = os.WriteFile(filePath, newData, 0666)
+	if err = os.WriteFile(filePath, newData, 0666); err != nil {
+		return newError("I/O Error: Error writing to file %s", filePath)
+	}
 	return NULL
 }
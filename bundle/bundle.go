@@ -0,0 +1,219 @@
+/*
+Package bundle packs a script and every module it imports into a standalone
+executable. The modules are appended to a copy of the esolang binary, which
+already embeds the standard library, and the binary runs them when it finds
+them at its end.
+*/
+package bundle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"esolang/lang-esolang/lexer"
+	"esolang/lang-esolang/parser"
+	"esolang/lang-esolang/token"
+	"esolang/lang-esolang/utils"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// magic ends every bundled executable, after the payload and its length.
+const magic = "\x00esolang-bundle1"
+
+// Bundle is a program and the sources of the user modules it imports. Files
+// are keyed by their slash separated path relative to the entry's directory,
+// so the builder's paths are not recorded and relative imports resolve the same
+// way once installed. The standard library is not included.
+type Bundle struct {
+	Entry string            `json:"entry"`
+	Files map[string]string `json:"files"`
+	Names map[string]string `json:"names"` // non relative import name -> key in Files
+}
+
+// Build reads entry and follows its imports. Imports of a computed name cannot
+// be followed and are returned as warnings, a missing module or a syntax error
+// fails the build. Packages from esolang.mod must already be in utils.Packages.
+func Build(entry string) (*Bundle, []string, error) {
+	entry, err := filepath.Abs(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	b := &Bundle{Entry: entry, Files: map[string]string{}, Names: map[string]string{}}
+	var warnings []string
+
+	queue := []string{entry}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if _, done := b.Files[path]; done {
+			continue
+		}
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		b.Files[path] = string(code)
+
+		p := parser.New(lexer.New(path, string(code)))
+		p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			return nil, nil, errors.New(strings.Join(p.Errors(), "\n"))
+		}
+
+		names, dynamic := imports(path, string(code))
+		warnings = append(warnings, dynamic...)
+		for _, name := range names {
+			if utils.IsBuiltinModule(name) {
+				continue
+			}
+			resolved := utils.ResolveModule(name, filepath.Dir(path))
+			if resolved == "" {
+				return nil, nil, fmt.Errorf("%s: no module named '%s'", path, name)
+			}
+			if !utils.IsRelativeModule(name) && !filepath.IsAbs(name) {
+				b.Names[name] = resolved
+			}
+			queue = append(queue, resolved)
+		}
+	}
+	root := filepath.Dir(entry)
+	b.rebase(func(path string) string {
+		rel, _ := filepath.Rel(root, path)
+		return filepath.ToSlash(rel)
+	})
+	return b, warnings, nil
+}
+
+// rebase replaces the path of every file, in Entry, Files and Names, with name(path).
+func (b *Bundle) rebase(name func(path string) string) {
+	files := make(map[string]string, len(b.Files))
+	for path, code := range b.Files {
+		files[name(path)] = code
+	}
+	for imported, path := range b.Names {
+		b.Names[imported] = name(path)
+	}
+	b.Entry, b.Files = name(b.Entry), files
+}
+
+// imports returns the module names a file imports with a string literal, and a
+// warning for each import of a computed name.
+func imports(path, code string) (names, dynamic []string) {
+	l := lexer.New(path, code)
+	var tokens []token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+	at := func(i int, t token.TokenType) bool {
+		return i < len(tokens) && tokens[i].Type == t
+	}
+
+	for i, tok := range tokens {
+		switch {
+		case tok.Type == token.IMPORT && at(i+1, token.LPAREN):
+			// import("name")
+			if at(i+2, token.STRING) && at(i+3, token.RPAREN) {
+				names = append(names, tokens[i+2].Literal)
+			} else {
+				dynamic = append(dynamic, fmt.Sprintf("%s:%d: import of a computed name is not bundled", path, tok.Line))
+			}
+		case tok.Type == token.IMPORT && at(i+1, token.STRING):
+			// import "name" as x
+			names = append(names, tokens[i+1].Literal)
+		case tok.Type == token.IDENT && tok.Literal == "from" && at(i+1, token.STRING) && at(i+2, token.IMPORT):
+			// from "name" import X
+			names = append(names, tokens[i+1].Literal)
+		}
+	}
+	return names, dynamic
+}
+
+// Write creates an executable at output: a copy of the esolang binary exe, without
+// any bundle it carries, followed by the bundle.
+func (b *Bundle) Write(exe, output string) error {
+	program, err := os.ReadFile(exe)
+	if err != nil {
+		return err
+	}
+	if size, ok := payloadSize(program); ok {
+		program = program[:len(program)-size]
+	}
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	out.Write(program)
+	out.Write(payload)
+	binary.Write(&out, binary.LittleEndian, uint64(len(payload)))
+	out.WriteString(magic)
+	return os.WriteFile(output, out.Bytes(), 0755)
+}
+
+// payloadSize is the length of the bundle at the end of data, with its trailer.
+func payloadSize(data []byte) (int, bool) {
+	trailer := 8 + len(magic)
+	if len(data) < trailer || string(data[len(data)-len(magic):]) != magic {
+		return 0, false
+	}
+	size := binary.LittleEndian.Uint64(data[len(data)-trailer:])
+	if size > uint64(len(data)-trailer) {
+		return 0, false
+	}
+	return int(size) + trailer, true
+}
+
+// Open returns the bundle appended to the executable at exe, or nil when it has none.
+func Open(exe string) (*Bundle, error) {
+	f, err := os.Open(exe)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	trailer := make([]byte, 8+len(magic))
+	if info.Size() < int64(len(trailer)) {
+		return nil, nil
+	}
+	if _, err := f.ReadAt(trailer, info.Size()-int64(len(trailer))); err != nil {
+		return nil, err
+	}
+	if string(trailer[8:]) != magic {
+		return nil, nil
+	}
+	size := binary.LittleEndian.Uint64(trailer)
+	if size > uint64(info.Size())-uint64(len(trailer)) {
+		return nil, errors.New("bundle: corrupt executable")
+	}
+
+	payload := make([]byte, size)
+	if _, err := f.ReadAt(payload, info.Size()-int64(len(trailer))-int64(size)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	b := &Bundle{}
+	if err := json.Unmarshal(payload, b); err != nil {
+		return nil, fmt.Errorf("bundle: corrupt executable: %s", err)
+	}
+	return b, nil
+}
+
+// Install places the bundled files in the absolute directory dir, where the
+// entry then is, and makes them the modules imports resolve to. Nothing is
+// written to dir.
+func (b *Bundle) Install(dir string) {
+	b.rebase(func(path string) string {
+		return filepath.Join(dir, filepath.FromSlash(path))
+	})
+	utils.Bundled = b.Files
+	utils.BundledNames = b.Names
+}
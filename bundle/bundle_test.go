@@ -0,0 +1,119 @@
+package bundle
+
+import (
+	"esolang/lang-esolang/evaluator"
+	"esolang/lang-esolang/lexer"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/parser"
+	"esolang/lang-esolang/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildAndRun(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"app/main.eso": `let util = import("./lib/util")
+from "colors/palette" import Red
+import "eso/math" as m
+let name = "dyn"
+let skipped = fn() { import(name) }
+let Result = [util::Greet("eso"), Red, m::Max(1, 2)]`,
+		"app/lib/util.eso":        `let fmt = import("../fmt"); func Greet(name) { fmt::Wrap(name) }`,
+		"app/fmt.eso":             `func Wrap(s) { "[" + s + "]" }`,
+		"pkgs/colors/palette.eso": `let Red = "#f00"`,
+		"fake-esolang":            "#!binary",
+	})
+	utils.AddPackage("colors", filepath.Join(dir, "pkgs", "colors"))
+	defer delete(utils.Packages, "colors")
+
+	b, warnings, err := Build(filepath.Join(dir, "app", "main.eso"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []string{}
+	for path := range b.Files {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	if b.Entry != "main.eso" || strings.Join(files, " ") != "../pkgs/colors/palette.eso fmt.eso lib/util.eso main.eso" {
+		t.Errorf("wrong files bundled: %s %v", b.Entry, files)
+	}
+	if b.Names["colors/palette"] != "../pkgs/colors/palette.eso" {
+		t.Errorf("wrong names: %v", b.Names)
+	}
+	if len(warnings) != 1 || !strings.HasSuffix(warnings[0], "main.eso:5: import of a computed name is not bundled") {
+		t.Errorf("wrong warnings: %v", warnings)
+	}
+
+	// building from a bundled executable replaces its bundle
+	exe := filepath.Join(dir, "fake-esolang")
+	output := filepath.Join(dir, "app-bin")
+	if err := b.Write(exe, output); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Write(output, output+"2"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(output + "2")
+	if !strings.HasPrefix(string(data), "#!binary{") || strings.Count(string(data), magic) != 1 {
+		t.Errorf("bundle not replaced: %q", data)
+	}
+
+	opened, err := Open(output + "2")
+	if err != nil || opened == nil || opened.Entry != b.Entry || len(opened.Files) != 4 {
+		t.Fatalf("wrong bundle read back %+v %v", opened, err)
+	}
+	if none, err := Open(exe); none != nil || err != nil {
+		t.Errorf("expected no bundle in a plain executable, got %+v %v", none, err)
+	}
+
+	// the bundled program runs without its sources
+	if err := os.RemoveAll(filepath.Join(dir, "app")); err != nil {
+		t.Fatal(err)
+	}
+	delete(utils.Packages, "colors")
+	os.RemoveAll(filepath.Join(dir, "pkgs"))
+	opened.Install(filepath.Join(dir, "elsewhere", "bin"))
+	defer func() { utils.Bundled, utils.BundledNames = nil, nil }()
+
+	p := parser.New(lexer.New(opened.Entry, opened.Files[opened.Entry]+"\nResult"))
+	evaluated := evaluator.Eval(p.ParseProgram(), object.NewEnvironment())
+	if evaluated == nil || evaluated.Inspect() != "[[eso], #f00, 2]" {
+		t.Errorf("wrong result from the bundle: %+v", evaluated)
+	}
+	if opened.Entry != filepath.Join(dir, "elsewhere", "bin", "main.eso") {
+		t.Errorf("entry not rebased: %s", opened.Entry)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"missing.eso": `let x = import("./nope")`,
+		"syntax.eso":  `let ok = import("./bad")`,
+		"bad.eso":     `let = 1`,
+	})
+	if _, _, err := Build(filepath.Join(dir, "missing.eso")); err == nil || err.Error() != filepath.Join(dir, "missing.eso")+": no module named './nope'" {
+		t.Errorf("wrong error for a missing module: %v", err)
+	}
+	if _, _, err := Build(filepath.Join(dir, "syntax.eso")); err == nil || !strings.Contains(err.Error(), "bad.eso: Line 1 Column 6 - expected next token to be IDENT") {
+		t.Errorf("wrong error for a syntax error: %v", err)
+	}
+}
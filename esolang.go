@@ -2,6 +2,7 @@ package main
 
 import (
 	_ "embed"
+	"esolang/lang-esolang/object"
 	"esolang/lang-esolang/repl"
 	"flag"
 	"os"
@@ -12,20 +13,34 @@ import (
 
 // build using: goreleaser release --snapshot --clean
 func main() {
+	if runBundle() {
+		return
+	}
 	replMode := flag.Bool("repl", false, "Start the repl")
+	permissions := permissionFlags()
 	logger := log.New(os.Stderr)
 	flag.Parse()
+	object.DefaultRuntime.Permissions = permissions()
 
 	if *replMode {
+		loadPackages(".", logger)
 		repl.Start(os.Stdin, os.Stdout)
 	}
 
+	if command, ok := commands[flag.Arg(0)]; ok {
+		if err := command(flag.Args()[1:]); err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(flag.Args()) > 0 {
 		file := flag.Args()[0]
 		inputFile, err := os.ReadFile(file)
 
 		if err != nil {
-			logger.Error("Error reading file %s", os.Args[1])
+			logger.Error("Error reading file %s", file)
 			os.Exit(1)
 		}
 		fileExtension := filepath.Ext(file)
@@ -34,12 +49,18 @@ func main() {
 			os.Exit(1)
 		}
 
-		repl.Execute(file, string(inputFile))
+		loadPackages(filepath.Dir(file), logger)
+		object.DefaultRuntime.Args = flag.Args()
+		repl.Execute(file, string(inputFile), flag.Args()[1:])
 	} else {
 		logger.Warn("No file provided. Please provide a file to run or use the -repl flag to start the repl.")
-		logger.Warn("Usage: esolang <path-to-filename>")
+		logger.Warn("Usage: esolang <path-to-filename> [args...]")
+		logger.Warn("Usage: esolang -sandbox [-allow-read=paths] [-allow-write=paths] [-allow-net] [-allow-run] [-allow-env] <path-to-filename>")
 		logger.Warn("Usage: esolang -repl")
+		logger.Warn("Usage: esolang build <path-to-filename> [-o output]")
+		logger.Warn("Usage: esolang init|get|vendor")
 		logger.Info("Starting repl...")
+		loadPackages(".", logger)
 		repl.Start(os.Stdin, os.Stdout)
 	}
 
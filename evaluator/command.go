@@ -0,0 +1,321 @@
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/object"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// commandPart is a piece of a command word, either literal text or the name
+// of a variable interpolated with ${name}.
+type commandPart struct {
+	text     string
+	variable bool
+}
+
+// commandWord is a single argument before interpolation. Interpolated values
+// are kept apart from the literal text so they are never split or re-parsed.
+type commandWord []commandPart
+
+// backTickOperation executes a command, or a pipeline of commands, and returns a hash object containing the result.
+// The hash includes 'stdout', 'stderr', and 'exitCode' fields.
+// If the command is empty or parsing fails, an error hash is returned.
+// No shell is involved: ${name} is replaced by the value of a variable as exactly one argument
+// (an array becomes one argument per element) and `|` connects commands in Go.
+func backTickOperation(node *ast.BacktickLiteral, env *object.Environment) object.Object {
+	if err := env.Runtime().CheckRun(); err != nil {
+		return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "PermissionError: %s", err)
+	}
+	command := strings.TrimSpace(node.Value)
+
+	// Split the command into the commands of the pipeline.
+	stages, err := parseCommandLine(command)
+	if err != nil {
+		// Return an error hash for parsing failure.
+		return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: "parse error: " + err.Error()},
+			&object.Integer{Value: -1})
+	}
+
+	// Check if the command is empty after parsing.
+	if len(stages) == 0 {
+		// Return an error hash for an empty command.
+		return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: "no command"},
+			&object.Integer{Value: -1})
+	}
+
+	pipeline := make([][]string, len(stages))
+	for i, stage := range stages {
+		for _, word := range stage {
+			args, errObj := interpolateCommandWord(node, word, env)
+			if errObj != nil {
+				return errObj
+			}
+			pipeline[i] = append(pipeline[i], args...)
+		}
+		if len(pipeline[i]) == 0 {
+			return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: "no command"},
+				&object.Integer{Value: -1})
+		}
+	}
+
+	rt := env.Runtime()
+	stdout, stderr, exitCode, err := runPipeline(rt.Context(), pipeline)
+	if ctxErr := rt.Context().Err(); ctxErr != nil {
+		return object.ContextError(ctxErr)
+	}
+	if err != nil {
+		// Handle failures to start a command (e.g., command not found).
+		return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: fmt.Sprintf("Failed to run '%s' -> %s\n", command, err.Error())},
+			&object.Integer{Value: -1})
+	}
+
+	if exitCode != 0 && rt.RaiseOnCommandFailure.Load() {
+		message := strings.TrimSpace(stderr)
+		if message == "" {
+			message = "no output on stderr"
+		}
+		return newError(node.Token.FileName, node.Token.Line, node.Token.Column,
+			"CommandError: '%s' exited with status %d: %s", command, exitCode, message)
+	}
+
+	// Create a hash with 'stdout', 'stderr', and 'exitCode' fields.
+	return createCommandExecHash(&object.String{Value: stdout}, &object.String{Value: stderr},
+		&object.Integer{Value: exitCode})
+}
+
+// interpolateCommandWord replaces the variables of a word with their values.
+func interpolateCommandWord(node *ast.BacktickLiteral, word commandWord, env *object.Environment) ([]string, object.Object) {
+	lookup := func(name string) (object.Object, object.Object) {
+		if val, ok := env.Get(name); ok {
+			return val, nil
+		}
+		return nil, newError(node.Token.FileName, node.Token.Line, node.Token.Column, "cannot find '%s' in scope", name)
+	}
+
+	// A word that is only an array variable expands to one argument per element.
+	if len(word) == 1 && word[0].variable {
+		val, errObj := lookup(word[0].text)
+		if errObj != nil {
+			return nil, errObj
+		}
+		if arr, ok := val.(*object.Array); ok {
+			args := make([]string, len(arr.Elements))
+			for i, element := range arr.Elements {
+				args[i] = commandArgument(element)
+			}
+			return args, nil
+		}
+		return []string{commandArgument(val)}, nil
+	}
+
+	var out strings.Builder
+	for _, part := range word {
+		if !part.variable {
+			out.WriteString(part.text)
+			continue
+		}
+		val, errObj := lookup(part.text)
+		if errObj != nil {
+			return nil, errObj
+		}
+		out.WriteString(commandArgument(val))
+	}
+	return []string{out.String()}, nil
+}
+
+func commandArgument(val object.Object) string {
+	if s, ok := val.(*object.String); ok {
+		return s.Value
+	}
+	return val.Inspect()
+}
+
+// runPipeline starts every command with its stdout connected to the stdin of the next one
+// and waits for all of them. The exit code is the one of the last command, like in a shell.
+// The commands are killed once ctx is done.
+func runPipeline(ctx context.Context, pipeline [][]string) (string, string, int64, error) {
+	cmds := make([]*exec.Cmd, len(pipeline))
+	stderrs := make([]bytes.Buffer, len(pipeline))
+	var stdout bytes.Buffer
+
+	for i, args := range pipeline {
+		cmds[i] = exec.CommandContext(ctx, filepath.Clean(args[0]), args[1:]...)
+		cmds[i].Stderr = &stderrs[i]
+		if i > 0 {
+			pipe, err := cmds[i-1].StdoutPipe()
+			if err != nil {
+				return "", "", -1, err
+			}
+			cmds[i].Stdin = pipe
+		}
+	}
+	cmds[len(cmds)-1].Stdout = &stdout
+
+	for i, cmd := range cmds {
+		if err := cmd.Start(); err != nil {
+			for _, started := range cmds[:i] {
+				_ = started.Process.Kill()
+				_ = started.Wait()
+			}
+			return "", "", -1, err
+		}
+	}
+
+	var exitCode int64
+	for _, cmd := range cmds {
+		err := cmd.Wait()
+		exitCode = 0
+		if err != nil {
+			var exitError *exec.ExitError
+			if !errors.As(err, &exitError) {
+				return "", "", -1, err
+			}
+			exitCode = int64(exitError.ExitCode())
+		}
+	}
+
+	var stderr strings.Builder
+	for i := range stderrs {
+		_, _ = io.Copy(&stderr, &stderrs[i])
+	}
+	return stdout.String(), stderr.String(), exitCode, nil
+}
+
+// createCommandExecHash Create a hash with 'stdout', 'stderr', and 'code' fields.
+func createCommandExecHash(stdoutObj, stderrObj, errorObj object.Object) object.Object {
+	// Create keys for the hash.
+	stdoutKey := &object.String{Value: "stdout"}
+	stderrKey := &object.String{Value: "stderr"}
+	exitCodeKey := &object.String{Value: "exitCode"}
+
+	// Populate the hash with key-value pairs.
+	hashPairs := map[object.HashKey]object.HashPair{
+		stdoutKey.HashKey():   {Key: stdoutKey, Value: stdoutObj},
+		stderrKey.HashKey():   {Key: stderrKey, Value: stderrObj},
+		exitCodeKey.HashKey(): {Key: exitCodeKey, Value: errorObj},
+	}
+
+	// Create and return the hash object.
+	return &object.Hash{Pairs: hashPairs}
+}
+
+// parseCommandLine splits a command into the words of each command of a pipeline.
+// Single quotes keep their content literal, double quotes still interpolate ${name},
+// `|` outside of quotes separates commands and `$${` produces a literal `${`.
+func parseCommandLine(command string) ([][]commandWord, error) {
+	var (
+		stages   [][]commandWord
+		words    []commandWord
+		word     commandWord
+		current  strings.Builder
+		inWord   bool
+		inQuotes bool
+		quote    rune
+	)
+
+	// flushText moves the pending literal text into the current word.
+	flushText := func() {
+		if current.Len() > 0 {
+			word = append(word, commandPart{text: current.String()})
+			current.Reset()
+		}
+	}
+	// flush appends the current word to the words of the current command.
+	flush := func() {
+		flushText()
+		if inWord {
+			if word == nil {
+				// an empty quoted argument such as ""
+				word = commandWord{{text: ""}}
+			}
+			words = append(words, word)
+		}
+		word = nil
+		inWord = false
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case inQuotes && c == quote:
+			// The matching closing quote ends the quoted text but not the word.
+			inQuotes = false
+		case !inQuotes && (c == '\'' || c == '"'):
+			inQuotes = true
+			quote = c
+			inWord = true
+		case !inQuotes && unicode.IsSpace(c):
+			flush()
+		case !inQuotes && c == '|':
+			flush()
+			if len(words) == 0 {
+				return nil, fmt.Errorf("missing command before '|' in command line: %s", command)
+			}
+			stages = append(stages, words)
+			words = nil
+		case c == '$' && (!inQuotes || quote == '"'):
+			inWord = true
+			if i+2 < len(runes) && runes[i+1] == '$' && runes[i+2] == '{' {
+				current.WriteString("${")
+				i += 2
+				continue
+			}
+			if i+1 >= len(runes) || runes[i+1] != '{' {
+				current.WriteRune(c)
+				continue
+			}
+			end := i + 2
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unclosed '${' in command line: %s", command)
+			}
+			name := strings.TrimSpace(string(runes[i+2 : end]))
+			if !isCommandVariable(name) {
+				return nil, fmt.Errorf("invalid variable name '%s' in command line: %s", name, command)
+			}
+			flushText()
+			word = append(word, commandPart{text: name, variable: true})
+			i = end
+		default:
+			current.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	// If still inside quotes at the end of parsing, return an error for unclosed quotes.
+	if inQuotes {
+		return nil, fmt.Errorf("unclosed quote in command line: %s", command)
+	}
+
+	flush()
+	if len(words) == 0 {
+		if len(stages) > 0 {
+			return nil, fmt.Errorf("missing command after '|' in command line: %s", command)
+		}
+		return nil, nil
+	}
+	return append(stages, words), nil
+}
+
+func isCommandVariable(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || unicode.IsLetter(c) || i > 0 && unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
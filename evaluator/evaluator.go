@@ -1,23 +1,20 @@
 package evaluator
 
 import (
-	"bytes"
-	"errors"
 	"esolang/lang-esolang/ast"
 	"esolang/lang-esolang/builtins"
-	"esolang/lang-esolang/lexer"
 	"esolang/lang-esolang/object"
-	"esolang/lang-esolang/parser"
-	"esolang/lang-esolang/utils"
 	"fmt"
 	"math"
-	"os"
-	"os/exec"
-	"path/filepath"
+	"math/big"
+	"regexp"
 	"strings"
-	"unicode"
 )
 
+func init() {
+	object.CallFunction = callFunction
+}
+
 var (
 	// TRUE and FALSE are the only instances of the Boolean object - add lil' optimization instead of creating new instances
 	TRUE  = &object.Boolean{Value: true}
@@ -46,6 +43,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.IntegerLiteral:
 		return &object.Integer{Value: node.Value}
 
+	case *ast.BigIntegerLiteral:
+		return &object.BigInt{Value: node.Value}
+
 	case *ast.FloatLiteral:
 		return &object.Float{Value: node.Value}
 
@@ -64,6 +64,17 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
 
+	case *ast.RegexLiteral:
+		if node.Regexp != nil {
+			return &object.Regex{Value: node.Regexp}
+		}
+		// a node not built by the parser has no compiled pattern
+		re, err := regexp.Compile(node.Value)
+		if err != nil {
+			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "invalid regular expression: %s", err)
+		}
+		return &object.Regex{Value: re}
+
 	case *ast.InfixExpression:
 		left := Eval(node.Left, env)
 		if isError(left) {
@@ -73,11 +84,20 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(right) {
 			return right
 		}
+		if err := checkInfixSize(env, node.Operator, left, right); err != nil {
+			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "%s", err.Message)
+		}
 		return evalInfixExpression(node.Operator, node, left, right)
 	case *ast.WhileLoopExpression:
 		return evalWhileLoopExpression(node, env)
 	case *ast.ImportExpression:
 		return evalImportExpression(node, env)
+	case *ast.ImportStatement:
+		return evalImportStatement(node, env)
+	case *ast.SpawnExpression:
+		return evalSpawnExpression(node, env)
+	case *ast.AwaitExpression:
+		return evalAwaitExpression(node, env)
 	case *ast.ObjectCallExpression:
 		return evalObjectCallExpression(node, env)
 	case *ast.LetStatement:
@@ -102,7 +122,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "expected identifier on left got=%T", node.Left)
 		}
 	case *ast.BacktickLiteral:
-		return backTickOperation(node.Value)
+		return backTickOperation(node, env)
 	case *ast.AssignStatement:
 		return evalAssignStatement(node, env)
 	case *ast.Identifier:
@@ -111,12 +131,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.FunctionLiteral:
 		params := node.Parameters
 		body := node.Body
-		return &object.Function{Parameters: params, Body: body, Env: env}
+		return &object.Function{Parameters: params, Body: body, Env: env, Async: node.Async}
 	case *ast.FunctionDefineLiteral:
 		params := node.Parameters
 		body := node.Body
 		// defaults := node.Defaults
-		env.Set(node.TokenLiteral(), &object.Function{Parameters: params, Env: env, Body: body})
+		env.Set(node.TokenLiteral(), &object.Function{Name: node.TokenLiteral(), Parameters: params, Env: env, Body: body, Async: node.Async})
 		return NULL
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
@@ -154,7 +174,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if len(args) == 1 && isError(args[0]) {
 			return args[0]
 		}
-		return applyFunction(node, function, args)
+		return applyFunction(node, function, args, env.Runtime())
 
 	case *ast.ReturnStatement:
 		value := Eval(node.ReturnValue, env)
@@ -219,7 +239,7 @@ func evalImportExpression(ie *ast.ImportExpression, env *object.Environment) obj
 	}
 
 	if s, ok := name.(*object.String); ok {
-		attrs := Module(ie, s.Value)
+		attrs := Module(env.Runtime(), ie.Token.FileName, s.Value)
 		if isError(attrs) {
 			return attrs
 		}
@@ -231,7 +251,11 @@ func evalImportExpression(ie *ast.ImportExpression, env *object.Environment) obj
 func evalWhileLoopExpression(flExpression *ast.WhileLoopExpression, env *object.Environment) object.Object {
 	var result object.Object
 
+	rt := env.Runtime()
 	for {
+		if err := rt.Step(); err != nil {
+			return newError(flExpression.Token.FileName, flExpression.Token.Line, flExpression.Token.Column, "%s", err.Message)
+		}
 		condition := Eval(flExpression.Condition, env)
 		if isError(condition) {
 			return condition
@@ -239,6 +263,9 @@ func evalWhileLoopExpression(flExpression *ast.WhileLoopExpression, env *object.
 
 		if isTruthy(condition) {
 			result = Eval(flExpression.Consequence, env)
+			if result != nil && (result.Type() == object.ERROR_OBJ || result.Type() == object.RETURN_VALUE_OBJ) {
+				return result
+			}
 		} else {
 			break
 		}
@@ -287,7 +314,7 @@ func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object
 		return val
 	}
 	if builtin, ok := builtins.Builtins[node.Value]; ok {
-		return builtin
+		return builtin.Bind(env.Runtime())
 	}
 	return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "cannot find '%s' in scope", node.Value)
 }
@@ -314,6 +341,10 @@ func evalInfixExpression[T InfixExpressions](operator string, node T, leftOperan
 	switch node := any(node).(type) {
 	case *ast.InfixExpression:
 		switch {
+		case isBigNumberOperation(leftOperand, rightOperand):
+			return evalBigNumberInfixExpression(node, operator, leftOperand, rightOperand)
+		case isTimeOperation(operator, leftOperand, rightOperand):
+			return evalTimeInfixExpression(node, operator, leftOperand, rightOperand)
 		case leftOperand.Type() == object.INTEGER_OBJ && rightOperand.Type() == object.INTEGER_OBJ:
 			return evalIntegerInfixExpression(node, operator, leftOperand, rightOperand)
 		case leftOperand.Type() == object.FLOAT_OBJ && rightOperand.Type() == object.FLOAT_OBJ:
@@ -342,6 +373,10 @@ func evalInfixExpression[T InfixExpressions](operator string, node T, leftOperan
 
 	case *ast.AssignStatement:
 		switch {
+		case isBigNumberOperation(leftOperand, rightOperand):
+			return evalBigNumberInfixExpression(node, operator, leftOperand, rightOperand)
+		case isTimeOperation(operator, leftOperand, rightOperand):
+			return evalTimeInfixExpression(node, operator, leftOperand, rightOperand)
 		case leftOperand.Type() == object.INTEGER_OBJ && rightOperand.Type() == object.INTEGER_OBJ:
 			return evalIntegerInfixExpression(node, operator, leftOperand, rightOperand)
 		case leftOperand.Type() == object.FLOAT_OBJ && rightOperand.Type() == object.FLOAT_OBJ:
@@ -450,16 +485,16 @@ func evalIntegerInfixExpression[T InfixExpressions](node T, operator string, lef
 	case *ast.InfixExpression:
 		switch operator {
 		case "+":
-			return &object.Integer{Value: leftValue + rightValue}
+			return addIntegers(leftValue, rightValue)
 		case "-":
-			return &object.Integer{Value: leftValue - rightValue}
+			return subtractIntegers(leftValue, rightValue)
 		case "*":
-			return &object.Integer{Value: leftValue * rightValue}
+			return multiplyIntegers(leftValue, rightValue)
 		case "/":
 			if rightValue == 0 {
 				return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "Can't divide by zero")
 			}
-			return &object.Integer{Value: leftValue / rightValue}
+			return divideIntegers(leftValue, rightValue)
 		case "%":
 			if rightValue == 0 {
 				return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "Can't divide by zero")
@@ -478,11 +513,11 @@ func evalIntegerInfixExpression[T InfixExpressions](node T, operator string, lef
         case "!=":
 			return nativeBoolToBooleanObject(leftValue != rightValue)
 		case "-=":
-			return &object.Integer{Value: leftValue - rightValue}
+			return subtractIntegers(leftValue, rightValue)
 		case "*=":
-			return &object.Integer{Value: leftValue * rightValue}
+			return multiplyIntegers(leftValue, rightValue)
 		case "+=":
-			return &object.Integer{Value: leftValue + rightValue}
+			return addIntegers(leftValue, rightValue)
 		case "<=":
 			return nativeBoolToBooleanObject(leftValue <= rightValue)
 		case ">=":
@@ -494,16 +529,16 @@ func evalIntegerInfixExpression[T InfixExpressions](node T, operator string, lef
 	case *ast.AssignStatement:
 		switch operator {
 		case "+":
-			return &object.Integer{Value: leftValue + rightValue}
+			return addIntegers(leftValue, rightValue)
 		case "-":
-			return &object.Integer{Value: leftValue - rightValue}
+			return subtractIntegers(leftValue, rightValue)
 		case "*":
-			return &object.Integer{Value: leftValue * rightValue}
+			return multiplyIntegers(leftValue, rightValue)
 		case "/":
 			if rightValue == 0 {
 				return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "Can't divide by zero")
 			}
-			return &object.Integer{Value: leftValue / rightValue}
+			return divideIntegers(leftValue, rightValue)
 		case "%":
 			if rightValue == 0 {
 				return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "Can't divide by zero")
@@ -522,13 +557,13 @@ func evalIntegerInfixExpression[T InfixExpressions](node T, operator string, lef
 		case "is_not":
 			return nativeBoolToBooleanObject(leftValue != rightValue)
         case "-=":
-			return &object.Integer{Value: leftValue - rightValue}
+			return subtractIntegers(leftValue, rightValue)
 		case "*=":
-			return &object.Integer{Value: leftValue * rightValue}
+			return multiplyIntegers(leftValue, rightValue)
 		case "**":
-			return &object.Integer{Value: int64(math.Pow(float64(leftValue), float64(rightValue)))}
+			return powerIntegers(leftValue, rightValue)
 		case "+=":
-			return &object.Integer{Value: leftValue + rightValue}
+			return addIntegers(leftValue, rightValue)
 		case "<=":
 			return nativeBoolToBooleanObject(leftValue <= rightValue)
 		case ">=":
@@ -852,6 +887,16 @@ func evalPostfixExpression(node *ast.PostfixExpression, env *object.Environment,
 
 // evalMinusPrefixOperatorExpression evaluates the right object and returns a new object with the value negated
 func evalMinusPrefixOperatorExpression(node *ast.PrefixExpression, right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	case *object.BigInt:
+		return object.NewBigInt(new(big.Int).Neg(right.Value))
+	case *object.Decimal:
+		return right.Neg()
+	case *object.Duration:
+		return &object.Duration{Value: -right.Value}
+	}
 
 	if right.Type() != object.INTEGER_OBJ {
 		return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "unknown operator: -%s", right.Type())
@@ -862,7 +907,7 @@ func evalMinusPrefixOperatorExpression(node *ast.PrefixExpression, right object.
 	}
 	// retrieve & negate the value
 	value := right.(*object.Integer).Value
-	return &object.Integer{Value: -value}
+	return subtractIntegers(0, value)
 }
 
 // evalBangOperatorExpression evaluates the right object and returns a new object with the value negated
@@ -921,8 +966,12 @@ func evalStatements(statements []ast.Statement, env *object.Environment) object.
 
 func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
+	rt := env.Runtime()
 	for _, statement := range block.Statements {
-		result = Eval(statement, env)
+		if err := rt.Step(); err != nil {
+			return newError(block.Token.FileName, block.Token.Line, block.Token.Column, "%s", err.Message)
+		}
+		result = evalStatement(statement, env)
 		if result != nil {
 			resultType := result.Type()
 			if resultType == object.RETURN_VALUE_OBJ || resultType == object.ERROR_OBJ {
@@ -939,9 +988,26 @@ func evalObjectCallExpression(call *ast.ObjectCallExpression, env *object.Enviro
 	if objectValue == nil {
 		return newError(call.Token.FileName, call.Token.Line, call.Token.Column, "object is nil")
 	}
-	if method, ok := call.Call.(*ast.CallExpression); ok {
-		args := evalExpressions(call.Call.(*ast.CallExpression).Arguments, env)
+	if isError(objectValue) {
+		return objectValue
+	}
+	if call.Call == nil {
+		return newError(call.Token.FileName, call.Token.Line, call.Token.Column, "SyntaxError: expected a method call after `.`")
+	}
+	if method, ok := call.Call.(*ast.CallExpression); ok && method.Function != nil {
+		args := evalExpressions(method.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
 		ret := objectValue.InvokeMethod(method.Function.String(), *env, args...)
+		if err, ok := ret.(*object.Error); ok && !err.Located() {
+			return err.WithFrame(object.Frame{
+				Function: method.Function.String(),
+				File:     call.Token.FileName,
+				Line:     call.Token.Line,
+				Column:   call.Token.Column,
+			})
+		}
 		if ret != nil {
 			return ret
 		}
@@ -964,6 +1030,9 @@ func evalAssignStatement(node *ast.AssignStatement, env *object.Environment) (va
 			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "%s is unknown", node.Name.String())
 		}
 
+		if err := checkInfixSize(env, "+=", current, evaluated); err != nil {
+			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "%s", err.Message)
+		}
 		res := evalInfixExpression("+=", node, current, evaluated)
 		if isError(res) {
 			return res
@@ -995,6 +1064,9 @@ func evalAssignStatement(node *ast.AssignStatement, env *object.Environment) (va
 			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "%s is unknown", node.Name.String())
 		}
 
+		if err := checkInfixSize(env, "*=", current, evaluated); err != nil {
+			return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "%s", err.Message)
+		}
 		res := evalInfixExpression("*=", node, current, evaluated)
 		if isError(res) {
 			return res
@@ -1012,7 +1084,7 @@ func evalAssignStatement(node *ast.AssignStatement, env *object.Environment) (va
 func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var evaluatedResult object.Object
 	for _, statement := range program.Statements {
-		evaluatedResult = Eval(statement, env)
+		evaluatedResult = evalStatement(statement, env)
 		switch evaluatedResult := evaluatedResult.(type) {
 		case *object.ReturnValue:
 			return evaluatedResult.Value
@@ -1023,188 +1095,129 @@ func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	return evaluatedResult
 }
 
-// backTickOperation executes a shell command and returns a hash object containing the result.
-// The hash includes 'stdout', 'stderr', and 'code' fields.
-// If the command is empty or parsing fails, an error hash is returned.
-func backTickOperation(command string) object.Object {
-	var (
-		args []string
-		err  error
-	)
-
-	// Trim leading and trailing whitespace from the command.
-	if command = strings.TrimSpace(command); command != "" {
-		// Split the command into arguments.
-		if args, err = parseCommandLine(command); err != nil {
-			// Return an error hash for parsing failure.
-			return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: "parse error: " + err.Error()},
-				&object.Integer{Value: -1})
-		}
-	}
-
-	// Check if the command is empty after parsing.
-	if len(args) == 0 {
-		// Return an error hash for an empty command.
-		return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: "no command"},
-			&object.Integer{Value: -1})
-	}
-
-	// Run the command.
-	cmd := exec.Command(filepath.Clean(args[0]), args[1:]...)
-
-	// Capture the command's stdout and stderr.
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+// applyFunction calls fn for a call expression, rt is the runtime of the calling scope.
+func applyFunction(node *ast.CallExpression, fn object.Object, args []object.Object, rt *object.Runtime) object.Object {
+	var result object.Object
+	switch fn := fn.(type) {
 
-	var exitCode int64 = 0
+	case *object.Function:
+		result = runFunction(fn, args)
 
-	// Execute the command and handle errors.
-	err = cmd.Run()
-	if err != nil {
-		var exitError *exec.ExitError
-		if !errors.As(err, &exitError) {
-			// Handle non-ExitError errors (e.g., command not found).
-			return createCommandExecHash(&object.String{Value: ""}, &object.String{Value: fmt.Sprintf("Failed to run '%s' -> %s\n", command, err.Error())},
-				&object.Integer{Value: -1})
+	case *object.Builtin:
+		result = fn.Call(rt, args...)
+		if err, ok := result.(*object.Error); ok && err.Located() {
+			// raised by a callback of the builtin, which knows where
+			return err
 		}
-		exitCode = int64(exitError.ExitCode())
+	default:
+		return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "not a function: %s", fn.Type())
 	}
-
-	// Create a hash with 'stdout', 'stderr', and 'code' fields.
-	return createCommandExecHash(&object.String{Value: stdout.String()}, &object.String{Value: stderr.String()},
-		&object.Integer{Value: exitCode})
+	if err, ok := result.(*object.Error); ok {
+		return err.WithFrame(object.Frame{
+			Function: functionName(node, fn),
+			File:     node.Token.FileName,
+			Line:     node.Token.Line,
+			Column:   node.Token.Column,
+		})
+	}
+	return result
 }
 
-// createCommandExecHash Create a hash with 'stdout', 'stderr', and 'code' fields.
-func createCommandExecHash(stdoutObj, stderrObj, errorObj object.Object) object.Object {
-	// Create keys for the hash.
-	stdoutKey := &object.String{Value: "stdout"}
-	stderrKey := &object.String{Value: "stderr"}
-	exitCodeKey := &object.String{Value: "exitCode"}
-
-	// Populate the hash with key-value pairs.
-	hashPairs := map[object.HashKey]object.HashPair{
-		stdoutKey.HashKey():   {Key: stdoutKey, Value: stdoutObj},
-		stderrKey.HashKey():   {Key: stderrKey, Value: stderrObj},
-		exitCodeKey.HashKey(): {Key: exitCodeKey, Value: errorObj},
+// functionName is the name of fn in tracebacks: the name it was defined with,
+// else the name it was called by.
+func functionName(node *ast.CallExpression, fn object.Object) string {
+	if f, ok := fn.(*object.Function); ok && f.Name != "" {
+		return f.Name
 	}
-
-	// Create and return the hash object.
-	return &object.Hash{Pairs: hashPairs}
+	if ident, ok := node.Function.(*ast.Identifier); ok {
+		return ident.Value
+	}
+	return "<fn>"
 }
 
-func parseCommandLine(command string) ([]string, error) {
-	var args []string
-	var current strings.Builder
-	inQuotes := false
-	var quoteChar rune
-
-	// flush appends the current argument to the args slice and resets the current string builder.
-	flush := func() {
-		if current.Len() > 0 {
-			args = append(args, current.String())
-			current.Reset()
-		}
-	}
+// callFunction applies fn outside of a call expression, e.g. when a builtin
+// invokes a callback supplied by the script.
+func callFunction(fn object.Object, args ...object.Object) object.Object {
+	return Call(nil, fn, args...)
+}
 
-	// Iterate through each character in the command string.
-	for _, c := range command {
-		switch {
-		case unicode.IsSpace(c) && !inQuotes:
-			// If a space is encountered outside of quotes, flush the current argument.
-			flush()
-		case (c == '\'' || c == '"') && !inQuotes:
-			// If a single or double quote is encountered and we're not inside quotes,
-			// mark the start of quoted text and record the quote character.
-			inQuotes = true
-			quoteChar = c
-		case c == quoteChar && inQuotes:
-			// If the matching closing quote is found while inside quotes, mark the end of quoted text
-			// and flush the current argument.
-			inQuotes = false
-			flush()
-		default:
-			// Otherwise, append the character to the current argument.
-			current.WriteRune(c)
+// Call applies a user function or a builtin to args, builtins use the runtime
+// rt, DefaultRuntime when nil. It is how a host calls into a script, a Go
+// panic of the call is returned as an InternalError.
+func Call(rt *object.Runtime, fn object.Object, args ...object.Object) (result object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = panicError(nil, r)
 		}
+	}()
+	switch fn := fn.(type) {
+	case *object.Function:
+		return runFunction(fn, args)
+	case *object.Builtin:
+		return fn.Call(rt, args...)
+	default:
+		return object.NewError("not a function: %s", fn.Type())
 	}
+}
 
-	// If still inside quotes at the end of parsing, return an error for unclosed quotes.
-	if inQuotes {
-		return nil, fmt.Errorf("unclosed quote in command line: %s", command)
+// runFunction evaluates the body of fn, an async function starts a task instead.
+func runFunction(fn *object.Function, args []object.Object) object.Object {
+	if fn.Async {
+		return object.NewTask(func() object.Object {
+			return evalFunctionBody(fn, args)
+		})
 	}
-
-	// Flush any remaining argument and return the parsed arguments.
-	flush()
-	return args, nil
+	return evalFunctionBody(fn, args)
 }
 
-func Module(node *ast.ImportExpression, name string) object.Object {
-	error := func(message string) object.Object {
-		return &object.Error{
-			Message: message,
-		}
+// evalFunctionBody evaluates the body of fn with args bound, the call counts
+// towards the call depth limit of the function's runtime.
+func evalFunctionBody(fn *object.Function, args []object.Object) object.Object {
+	if len(args) < len(fn.Parameters) {
+		return newError(fn.Body.Token.FileName, fn.Body.Token.Line, fn.Body.Token.Column,
+			"TypeError: function missing required argument '%s' (%d given)", fn.Parameters[len(args)].Value, len(args))
 	}
-
-	parseAndGetModuleHash := func(source, code string) object.Object {
-		l := lexer.New(source, string(code))
-		p := parser.New(l)
-
-		module := p.ParseProgram()
-		if len(p.Errors()) != 0 {
-			return error(strings.Join(p.Errors(), "\n"))
-		}
-
-		env := object.NewEnvironment()
-		objeEval := Eval(module, env)
-		switch encounterError := objeEval.(type) {
-		case *object.Error:
-			return encounterError
-		}
-
-		return env.ExportedHash()
+	rt := fn.Env.Runtime()
+	if err := rt.EnterCall(); err != nil {
+		return newError(fn.Body.Token.FileName, fn.Body.Token.Line, fn.Body.Token.Column, "%s", err.Message)
 	}
+	defer rt.ExitCall()
+	extendedEnv := extendFunctionEnv(fn, args)
+	evaluated := Eval(fn.Body, extendedEnv)
+	return unwrapReturnValue(evaluated)
+}
 
-	if utils.IsBuiltinModule(name) {
-		// TODO: line numbers and column numbers for built-in modules
-		// errors should be the node's line and column numbers instead
-		moduleName := strings.Split(name, "/")[1]
-		moduleCode, err := builtins.GetStdLib(moduleName)
-		if err != nil {
-			return error(err.Error())
+// checkInfixSize returns an error when + or * would build a string or array
+// over the collection size limit, before it is built.
+func checkInfixSize(env *object.Environment, operator string, left, right object.Object) *object.Error {
+	size := int64(-1)
+	switch l := left.(type) {
+	case *object.String:
+		switch r := right.(type) {
+		case *object.String:
+			if operator == "+" || operator == "+=" {
+				size = int64(len(l.Value)) + int64(len(r.Value))
+			}
+		case *object.Integer:
+			if (operator == "*" || operator == "*=") && r.Value > 0 && len(l.Value) > 0 {
+				size = math.MaxInt64
+				if r.Value <= math.MaxInt64/int64(len(l.Value)) {
+					size = int64(len(l.Value)) * r.Value
+				}
+			}
+		}
+	case *object.Array:
+		if r, ok := right.(*object.Array); ok && (operator == "+" || operator == "+=") {
+			size = int64(len(l.Elements)) + int64(len(r.Elements))
 		}
-		return parseAndGetModuleHash(name, moduleCode)
-	}
-
-	filename := utils.FindModule(name)
-	if filename == "" {
-		return error(fmt.Sprintf("ImportError: no module named '%s'", name))
 	}
-
-	b, err := os.ReadFile(filename)
-	if err != nil {
-		return error(fmt.Sprintf("IOError: error reading module '%s': %s", name, err))
+	if size < 0 {
+		return nil
 	}
-
-	sourceName := strings.Split(filename, "/")
-	source := sourceName[len(sourceName)-2] + "/" + sourceName[len(sourceName)-1]
-	return parseAndGetModuleHash(source, string(b))
-}
-
-func applyFunction(node *ast.CallExpression, fn object.Object, args []object.Object) object.Object {
-	switch fn := fn.(type) {
-
-	case *object.Function:
-		extendedEnv := extendFunctionEnv(fn, args)
-		evaluated := Eval(fn.Body, extendedEnv)
-		return unwrapReturnValue(evaluated)
-
-	case *object.Builtin:
-		return fn.Fn(args...)
-	default:
-		return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "not a function: %s", fn.Type())
+	if size > math.MaxInt {
+		size = math.MaxInt
 	}
+	return env.Runtime().CheckSize(int(size))
 }
 
 func unwrapReturnValue(evaluated object.Object) object.Object {
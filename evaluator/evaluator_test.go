@@ -1,10 +1,21 @@
 package evaluator
 
 import (
+	"encoding/json"
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/builtins"
 	"esolang/lang-esolang/lexer"
 	"esolang/lang-esolang/object"
 	"esolang/lang-esolang/parser"
+	"esolang/lang-esolang/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 const FILE = "<test>"
@@ -424,6 +435,763 @@ func TestErrorHandling(t *testing.T) {
 	}
 }
 
+func TestPanicRecovery(t *testing.T) {
+	builtins.RegisterBuiltin("test_panic", func(args ...object.Object) object.Object {
+		panic("boom")
+	})
+	defer delete(builtins.Builtins, "test_panic")
+
+	tests := []struct {
+		input           string
+		expectedMessage string
+	}{
+		{"let a = 1;\nlet b = test_panic();", FILE + ":2:5: InternalError: boom"},
+		{"func f() {\n  test_panic()\n}\nf()", FILE + ":2:14: InternalError: boom"},
+		{"let f = fn(a, b) { a }; f(1)", FILE + ":1:19: TypeError: function missing required argument 'b' (1 given)"},
+		{`"abc".`, FILE + ":1:7: value of type `STRING` has no member `()`"},
+		{`missing.upper_case()`, FILE + ":1:9: cannot find 'missing' in scope"},
+	}
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T(%+v)", test.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != test.expectedMessage {
+			t.Errorf("wrong error message. expected=%q, got=%q", test.expectedMessage, errObj.Message)
+		}
+	}
+
+	if evaluated := Call(nil, builtins.Builtins["test_panic"]); evaluated.Inspect() != "ERROR: InternalError: boom" {
+		t.Errorf("a panic in Call should be an error, got %+v", evaluated)
+	}
+}
+
+func TestTracebacks(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 / 0", FILE + ":1:4: Can't divide by zero"},
+		{"func inner(x) {\n  x / 0\n}\nfunc outer() {\n  inner(1)\n}\nouter()", `Traceback (most recent call last):
+  File "<test>", line 7, in <module>
+  File "<test>", line 5, in outer
+  File "<test>", line 2, in inner
+Can't divide by zero`},
+		{"func f() {\n  \"a\".nope()\n}\nlet g = fn() { f() }\ng()", `Traceback (most recent call last):
+  File "<test>", line 5, in <module>
+  File "<test>", line 4, in g
+  File "<test>", line 2, in f
+value of type ` + "`STRING` has no member `nope()`"},
+		{"func down(n) {\n  down(n + 1)\n}\ndown(0)", `Traceback (most recent call last):
+  File "<test>", line 4, in <module>
+  File "<test>", line 2, in down
+  [Previous line repeated 49999 more times]
+  File "<test>", line 1, in down
+RecursionError: maximum call depth of 50000 exceeded`},
+	}
+	for _, test := range tests {
+		errObj, ok := testEval(test.input).(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q", test.input)
+			continue
+		}
+		if got := errObj.FormatTraceback(); got != test.expected {
+			t.Errorf("wrong traceback for %q. expected=\n%s\ngot=\n%s", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestRegexMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`re"^h".match("hello")`, "true"},
+		{`re"[0-9]+".find_all("a1b22c333")`, "[1, 22, 333]"},
+		{`re"[0-9]+".find_all("a1b22c333", 2)`, "[1, 22]"},
+		{`re"(?P<key>\w+)=(?P<value>\d+)".captures("id=42")["value"]`, "42"},
+		{`re"x".captures("abc")`, "null"},
+		{`re"(\w)(\d)".replace("a1 b2", "$2$1")`, "1a 2b"},
+		{`re"\d".replace_fn("a1b2", fn(m) { m + m })`, "a11b22"},
+		{`re"\s*,\s*".split("a , b,c")`, "[a, b, c]"},
+		{`let r = import("eso/regex"); r::FindAll("o", "foo")`, "[o, o]"},
+		{`let r = import("eso/regex"); r::Match(re"^f", "foo")`, "true"},
+		{`type_of(re"a")`, "REGEX"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestRegexLiteralCompiledOnce(t *testing.T) {
+	program := parser.New(lexer.New(FILE, `re"[a-z]+"`)).ParseProgram()
+	literal := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.RegexLiteral)
+	env := object.NewEnvironment()
+	first, second := Eval(program, env), Eval(program, env)
+	if first.(*object.Regex).Value != literal.Regexp || second.(*object.Regex).Value != literal.Regexp {
+		t.Errorf("expected every evaluation to use the pattern the parser compiled")
+	}
+}
+
+func TestNumberMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2.5 + 2.5", "5.0"},
+		{"-2.5", "-2.5"},
+		{"3.14159.round(2)", "3.14"},
+		{"2.5.round()", "3"},
+		{"(-2.5).floor()", "-3"},
+		{"2.1.ceil()", "3"},
+		{"7.9.to_int()", "7"},
+		{"(-2.5).abs()", "2.5"},
+		{"(0.0 / 1.0).is_nan()", "false"},
+		{"1.5.clamp(0, 1)", "1.0"},
+		{"3.14159.to_string(3)", "3.142"},
+		{"(-3).abs()", "3"},
+		{"10.clamp(0, 5)", "5"},
+		{"5.to_string(2)", "5.00"},
+		{"5.to_float()", "5.0"},
+		{`format("%.2f", 3.14159)`, "3.14"},
+		{`format("%d items at %s", 3, "noon")`, "3 items at noon"},
+		{`format("%d", 2.0)`, "2"},
+		{`format("%.1f%%", 50)`, "50.0%"},
+		{`format("%5.2f|%-3d|%x", 2.5, 7, 255)`, " 2.50|7  |ff"},
+		{`format("%d", 2.5)`, "ERROR: ValueError: format() verb %d cannot format FLOAT 2.5"},
+		{`format("%f", "a")`, "ERROR: ValueError: format() verb %f cannot format STRING a"},
+		{`format("%d and %d", 1)`, "ERROR: ValueError: format() layout has 2 verbs but got 1 values"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestMathModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"m::Sqrt(16)", "4.0"},
+		{"m::Pow(2, 10)", "1024"},
+		{"m::Pow(4, 0.5)", "2.0"},
+		{"m::Log2(8)", "3.0"},
+		{"m::Log10(1000)", "3.0"},
+		{"m::Exp(0)", "1.0"},
+		{"m::Cos(0)", "1.0"},
+		{"m::Floor(2.7)", "2"},
+		{"m::Ceil(2.2)", "3"},
+		{"m::Round(-2.5)", "-3"},
+		{"m::Inf", "+Inf"},
+		{"m::NaN.is_nan()", "true"},
+		{"m::Max(3, 9, 4)", "9"},
+		{"m::Min([1.5, 0.5, 2])", "0.5"},
+		{"m::Sum([1, 2, 3])", "6"},
+		{"m::Sum([1, 2.5])", "3.5"},
+		{"m::Mean([1, 2, 3, 4])", "2.5"},
+		{"m::Median([3, 1, 2, 10])", "2.5"},
+		{"m::Stddev([2, 4, 4, 4, 5, 5, 7, 9])", "2.0"},
+		{"m::Gcd(12, 18)", "6"},
+		{"m::Lcm(4, 6)", "12"},
+		{"m::Sum([9223372036854775807, 1])", "9223372036854775808"},
+		{"m::Sum([9223372036854775807, 1, -1])", "9223372036854775807"},
+		{"m::Gcd(-9223372036854775807 - 1, 0)", "9223372036854775808"},
+		{"m::Gcd(-12, 18, 0)", "6"},
+		{"m::Lcm(9223372036854775807, 2)", "18446744073709551614"},
+		{"m::Lcm(4, 0, 6)", "0"},
+		{"let a = m::Random(7); let b = m::Random(7); a.int(1000) == b.int(1000)", "true"},
+		{"m::Random(7).int(5, 5)", "5"},
+		{"let n = m::Random(1).int(-9223372036854775807, 9223372036854775807); n >= -9223372036854775807", "true"},
+		{"let r = m::Random(1); [r.int(-5, 9223372036854775807) >= -5, r.int(-5, 9223372036854775807) >= -5, r.int(-5, 9223372036854775807) >= -5]", "[true, true, true]"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let m = import("eso/math"); ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestFsModule(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`fs::Exists(dir + "/a.txt")`, "false"},
+		{`let f = fs::Open(dir + "/a.txt", "w"); f.write("one\ntwo\nthree\n")`, "14"},
+		{`let f = fs::Open(dir + "/a.txt", "a"); f.write("four"); f.close(); f.is_closed()`, "true"},
+		{`fs::ReadLines(dir + "/a.txt")`, "[one, two, three, four]"},
+		{`let f = fs::Open(dir + "/a.txt"); f.read_line(); f.read_line()`, "two"},
+		{`let f = fs::Open(dir + "/a.txt"); f.read(3)`, "one"},
+		{`let f = fs::Open(dir + "/a.txt"); f.read(); f.read(1)`, "null"},
+		{`let f = fs::Open(dir + "/a.txt"); f.close(); f.read()`, "ERROR: ClosedFileError: File.read() on closed file '" + dir + "/a.txt'"},
+		{`let seen = []; fs::EachLine(dir + "/a.txt", fn(line, n) { seen.append(line); n < 2 }); seen`, "[one, two]"},
+		{`fs::Stat(dir + "/a.txt")["size"]`, "18"},
+		{`fs::Stat(dir)["is_dir"]`, "true"},
+		{`fs::MkdirAll(dir + "/sub/deep"); fs::Copy(dir + "/a.txt", dir + "/sub/b.txt"); fs::ListDir(dir)`, "[a.txt, sub]"},
+		{`fs::Rename(dir + "/sub/b.txt", dir + "/sub/c.txt"); fs::Glob(dir + "/sub/*.txt").length()`, "1"},
+		{`fs::Walk(dir + "/sub").length()`, "2"},
+		{`fs::Remove(dir + "/sub")`, "ERROR: DirectoryNotEmptyError: remove " + dir + "/sub: directory not empty"},
+		{`fs::RemoveAll(dir + "/sub"); fs::Exists(dir + "/sub")`, "false"},
+		{`fs::Stat(dir + "/missing")`, "ERROR: FileNotFoundError: stat " + dir + "/missing: no such file or directory"},
+		{`fs::Open(dir + "/a.txt", "x")`, "ERROR: ValueError: invalid file mode 'x', expected one of r, w, a, r+, w+, a+"},
+		{`fs::AppendFile(dir + "/b.txt", "line2\nline3\n"); fs::PrependFile(dir + "/b.txt", "line1"); fs::ReadLines(dir + "/b.txt")`, "[line1, line2, line3]"},
+		{`let f = fs::Open(dir + "/b.txt", "r+"); f.read_line(); f.write("XX"); f.close(); fs::ReadLines(dir + "/b.txt")`, "[line1, XXne2, line3]"},
+		{`let tmp = fs::TempDir(); let ok = fs::Stat(tmp)["is_dir"]; fs::RemoveAll(tmp); ok`, "true"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let fs = import("eso/fs"); let dir = "` + dir + `"; ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestProcessModule(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ESO_PROCESS_TEST", "set")
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`p::GetEnv("ESO_PROCESS_TEST")`, "set"},
+		{`p::GetEnv("ESO_PROCESS_MISSING")`, "null"},
+		{`p::GetEnv("ESO_PROCESS_MISSING", "fallback")`, "fallback"},
+		{`p::SetEnv("ESO_PROCESS_TEST", "changed"); p::Env()["ESO_PROCESS_TEST"]`, "changed"},
+		{`p::Args().length() > 0`, "true"},
+		{`p::Spawn(["echo", "hello", "world"])["stdout"]`, "hello world\n"},
+		{`p::Spawn(["cat"], {"stdin": "piped"})["stdout"]`, "piped"},
+		{`p::Spawn(["sh", "-c", "echo $ESO_PROCESS_TEST"], {"env": {"ESO_PROCESS_TEST": "override"}})["stdout"]`, "override\n"},
+		{`p::Spawn(["pwd"], {"cwd": dir})["stdout"]`, dir + "\n"},
+		{`p::Spawn(["sh", "-c", "exit 3"])["exitCode"]`, "3"},
+		{`p::Spawn(["sleep", "5"], {"timeout": 50})["timedOut"]`, "true"},
+		{`let lines = []; p::Spawn(["printf", "a\nb\n"], {"on_stdout": fn(line) { lines.append(line) }}); lines`, "[a, b]"},
+		{`p::Spawn([])`, "ERROR: ValueError: Spawn expects a non-empty command"},
+		{`p::Spawn(["echo"], {"shell": true})`, "ERROR: ValueError: unknown Spawn option 'shell'"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let p = import("eso/process"); let dir = "` + dir + `"; ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestBacktickCommands(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"`echo hello`[\"stdout\"]", "hello\n"},
+		{"let name = \"a  b; rm -rf /\"; `printf '%s|' ${name}`[\"stdout\"]", "a  b; rm -rf /|"},
+		{"let files = [\"x y\", \"z\"]; `printf <%s> ${files}`[\"stdout\"]", "<x y><z>"},
+		{"let n = 3; `echo v${n}.0 \"${n}\" '${n}'`[\"stdout\"]", "v3.0 3 ${n}\n"},
+		{"`echo $${HOME}`[\"stdout\"]", "${HOME}\n"},
+		{"`printf \"\" done`[\"stdout\"]", ""},
+		{"`printf 'b\\na\\nc\\n' | sort | head -n 2`[\"stdout\"]", "a\nb\n"},
+		{"`echo 'a|b'`[\"stdout\"]", "a|b\n"},
+		{"`sh -c 'exit 2'`[\"exitCode\"]", "2"},
+		{"`sh -c 'exit 4' | cat`[\"exitCode\"]", "0"},
+		{"`cat | sh -c 'exit 4'`[\"exitCode\"]", "4"},
+		{"`echo ${missing}`", "ERROR: <test>:1:2: cannot find 'missing' in scope"},
+		{"`echo |`[\"stderr\"]", "parse error: missing command after '|' in command line: echo |"},
+		{"`echo ${1x}`[\"stderr\"]", "parse error: invalid variable name '1x' in command line: echo ${1x}"},
+		{"let p = import(\"eso/process\"); p::RaiseOnFailure(true); `true`[\"exitCode\"]", "0"},
+		{"let p = import(\"eso/process\"); p::RaiseOnFailure(true); let r = `sh -c 'echo boom >&2; exit 2'`; p::RaiseOnFailure(false); r",
+			"ERROR: <test>:1:66: CommandError: 'sh -c 'echo boom >&2; exit 2'' exited with status 2: boom"},
+	}
+	t.Cleanup(func() { object.DefaultRuntime.RaiseOnCommandFailure.Store(false) })
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestCliModule(t *testing.T) {
+	setup := `let cli = import("eso/cli");
+	let app = cli::App("deploy", "Deploy the service");
+	app.option("env|e", "Target environment", "staging").flag("verbose|v", "Print more output").option("retries", "Retries", 2);
+	app.command("rollback", "Undo the last release").arg("service", "Service name");
+	`
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`app.parse([])["flags"]["env"]`, "staging"},
+		{`app.parse([])["command"]`, "null"},
+		{`app.parse(["--env", "prod"])["flags"]["env"]`, "prod"},
+		{`app.parse(["-e=prod"])["flags"]["env"]`, "prod"},
+		{`app.parse(["-v"])["flags"]["verbose"]`, "true"},
+		{`app.parse(["--verbose=false"])["flags"]["verbose"]`, "false"},
+		{`app.parse(["--retries", "5"])["flags"]["retries"] + 1`, "6"},
+		{`let p = app.parse(["rollback", "-v", "api", "--", "--raw"]); [p["command"], p["args"], p["flags"]["verbose"]]`, "[rollback, [api, --raw], true]"},
+		{`app.parse(["--nope"])`, "ERROR: UsageError: unknown option '--nope', see 'deploy --help'"},
+		{`app.parse(["ship"])`, "ERROR: UsageError: unknown command 'ship', see 'deploy --help'"},
+		{`app.parse(["--env"])`, "ERROR: UsageError: option '--env' requires a value, see 'deploy --help'"},
+		{`app.parse(["--retries", "many"])`, "ERROR: UsageError: option '--retries' expects an integer, got 'many', see 'deploy --help'"},
+		{`app.parse(["rollback"])`, "ERROR: UsageError: missing argument <service>, see 'deploy rollback --help'"},
+		{`app.parse([])["help"]`, "null"},
+		{`let p = app.parse(["rollback", "--help", "--nope"]); [p["command"], type_of(p["help"])]`, "[rollback, STRING]"},
+		{`app.parse(["-h"])["help"] == app.usage()`, "true"},
+		{`app.flag("env", "again")`, "ERROR: ValueError: Cli.flag() option 'env' is already declared"},
+		{`app.usage()`, "Usage: deploy [options] <command>\n\nDeploy the service\n\nCommands:\n  rollback  Undo the last release\n\n" +
+			"Options:\n  -e, --env <value>      Target environment (default: staging)\n  -v, --verbose          Print more output\n" +
+			"      --retries <value>  Retries (default: 2)\n  -h, --help             Show this help\n"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(setup + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestJsonModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`json::Parse("{\"a\": {\"b\": [1, 2.5, true, null, \"x\"]}}")["a"]["b"]`, "[1, 2.5, true, null, x]"},
+		{`json::Parse("[[1], {\"k\": -3}]")[1]["k"] + 1`, "-2"},
+		{`json::Parse("123456789012345678901234567890") + 1`, "123456789012345678901234567891"},
+		{`json::Parse("1e3")`, "1000.0"},
+		{`json::Parse("\"\\u00e9\"")`, "é"},
+		{`json::Parse("{\"a\": 1,\n \"b\": }")`, "ERROR: JSONError: invalid character '}' looking for beginning of value at line 2, column 7"},
+		{`json::Parse("[1, 2")`, "ERROR: JSONError: unexpected end of JSON input at line 1, column 6"},
+		{`json::Parse("{} x")`, "ERROR: JSONError: unexpected data after top-level value at line 1, column 4"},
+		{`json::Stringify({"b": [1, 2.5, json::Parse("null")], "a": {"c": "<q\"x>"}, 1: true})`, `{"1":true,"a":{"c":"<q\"x>"},"b":[1,2.5,null]}`},
+		{`json::Stringify([1, {"a": []}], 2)`, "[\n  1,\n  {\n    \"a\": []\n  }\n]"},
+		{`json::Stringify("tab\t")`, `"tab\t"`},
+		{`json::Stringify([1.0, 2.5, -3.0, json::Parse("1e21")])`, "[1.0,2.5,-3.0,1e+21]"},
+		{`type_of(json::Parse(json::Stringify(1.0)))`, "FLOAT"},
+		{`json::Stringify({"a": {1: "int", "1": "string"}})`, `ERROR: JSONError: duplicate key "1" at $.a`},
+		{`json::Stringify(BigInt("99999999999999999999"))`, "99999999999999999999"},
+		{`json::Stringify({"users": [{"cb": fn() { 1 }}]})`, "ERROR: JSONError: cannot encode value of type FUNCTION at $.users[0].cb"},
+		{`json::Stringify([import("eso/math")::Inf])`, "ERROR: JSONError: cannot encode +Inf at $[0]"},
+		{`let v = {"x": [1, {"y": "z"}]}; json::Parse(json::Stringify(v))["x"][1]["y"]`, "z"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let json = import("eso/json"); ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestHttpModule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			user, password, _ := r.BasicAuth()
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Add("X-Trace", "a")
+			w.Header().Add("X-Trace", "b")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"method":  r.Method,
+				"query":   r.URL.RawQuery,
+				"body":    string(body),
+				"type":    r.Header.Get("Content-Type"),
+				"token":   r.Header.Get("X-Token"),
+				"user":    user + ":" + password,
+				"bearer":  r.Header.Get("Authorization"),
+				"numbers": []int{1, 2},
+			})
+		case "/redirect":
+			http.Redirect(w, r, "/echo", http.StatusFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`http::Get(url + "/echo").status`, "200"},
+		{`http::Get(url + "/echo").ok`, "true"},
+		{`http::Get(url + "/echo").json()["numbers"]`, "[1, 2]"},
+		{`http::Get(url + "/echo").header("x-trace")`, "a, b"},
+		{`http::Get(url + "/echo").headers["content-type"]`, "application/json"},
+		{`http::Delete(url + "/echo").json()["method"]`, "DELETE"},
+		{`http::Request("propfind", url + "/echo").json()["method"]`, "PROPFIND"},
+		{`http::Get(url + "/echo?a=1", {"query": {"b": [2, 3]}}).json()["query"]`, "a=1&b=2&b=3"},
+		{`let r = http::Post(url + "/echo", {"body": {"n": [1, {"x": true}]}}).json(); [r["body"], r["type"]]`, `[{"n":[1,{"x":true}]}, application/json]`},
+		{`http::Put(url + "/echo", {"body": "raw", "headers": {"Content-Type": "text/plain"}}).json()["type"]`, "text/plain"},
+		{`http::Get(url + "/echo", {"headers": {"X-Token": "abc"}}).json()["token"]`, "abc"},
+		{`http::Get(url + "/echo", {"auth": ["eso", "pw"]}).json()["user"]`, "eso:pw"},
+		{`http::Get(url + "/echo", {"auth": "t0k"}).json()["bearer"]`, "Bearer t0k"},
+		{`http::Get(url + "/redirect").url == url + "/echo"`, "true"},
+		{`http::Get(url + "/redirect", {"redirects": 0}).status`, "302"},
+		{`http::Get(url + "/missing").status_text`, "Not Found"},
+		{`http::Get(url + "/missing").ok`, "false"},
+		{`http::Get(url + "/missing").json()`, "ERROR: JSONError: Response.json() body of GET " + server.URL + "/missing: unexpected data after top-level value at line 1, column 5"},
+		{`http::Get(url + "/slow", {"timeout": 50})`, "ERROR: TimeoutError: GET " + server.URL + "/slow timed out after 50ms"},
+		{`http::Get(url, {"retries": 3})`, "ERROR: ValueError: unknown request option 'retries'"},
+		{`Http(1, "x")`, "ERROR: Request type must be of type String, got INTEGER"},
+		{`Http("POST", url)`, "ERROR: POST request requires a body"},
+		{`Http("GET", url + "/echo")["Body"]["numbers"]`, "[1, 2]"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let http = import("eso/http"); let url = "` + server.URL + `"; ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestServerModule(t *testing.T) {
+	evaluated := testEval(`let server = import("eso/server");
+	let app = server::New();
+	app.get("/users/:id", fn(req) { server::Json({"id": req.param("id"), "sort": req.query["sort"]}) });
+	app.post("/echo", fn(req) { server::Text(req.method + " " + req.header("x-name") + " " + req.json()["n"].to_string(), 201) });
+	app.delete("/items/:id", fn(req) { server::Status(204) });
+	app.get("/plain", fn(req) { "hello" });
+	app.get("/hash", fn(req) { {"params": req.params} });
+	app.get("/fail", fn(req) { req.json() });
+	app;`)
+	app, ok := evaluated.(*object.Server)
+	if !ok {
+		t.Fatalf("expected a server, got %+v", evaluated)
+	}
+	ts := httptest.NewServer(app.Handler())
+	defer ts.Close()
+
+	tests := []struct {
+		method      string
+		path        string
+		body        string
+		status      int
+		contentType string
+		expected    string
+	}{
+		{"GET", "/users/42?sort=asc", "", 200, "application/json; charset=UTF-8", `{"id":"42","sort":"asc"}`},
+		{"POST", "/echo", `{"n": 7}`, 201, "text/plain; charset=UTF-8", "POST eso 7"},
+		{"DELETE", "/items/3", "", 204, "", ""},
+		{"GET", "/plain", "", 200, "text/plain; charset=UTF-8", "hello"},
+		{"GET", "/hash", "", 200, "application/json; charset=UTF-8", `{"params":{}}`},
+		{"GET", "/fail", "", 500, "text/plain; charset=UTF-8", "Internal Server Error"},
+		{"GET", "/missing", "", 404, "application/json; charset=UTF-8", "{\"message\":\"Not Found\"}\n"},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, ts.URL+test.path, strings.NewReader(test.body))
+		req.Header.Set("X-Name", "eso")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %s", test.method, test.path, err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		if res.StatusCode != test.status || res.Header.Get("Content-Type") != test.contentType || string(body) != test.expected {
+			t.Errorf("%s %s: expected %d %q %q, got %d %q %q", test.method, test.path,
+				test.status, test.contentType, test.expected, res.StatusCode, res.Header.Get("Content-Type"), body)
+		}
+	}
+}
+
+func TestCsvModule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.csv")
+	if err := os.WriteFile(path, []byte("name;age\nada;36\n\"grace; h\";85\nalan;41\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`csv::Parse("a,b\n1,2\n")`, "[[a, b], [1, 2]]"},
+		{`csv::Parse("name,age\nada,36\n", {"header": true})[0]["age"]`, "36"},
+		{`csv::Parse("\"x, \"\"y\"\"\",z\n")[0][0]`, `x, "y"`},
+		{`csv::Parse("a\tb\n# note\nc\td", {"delimiter": "\t", "comment": "#"})`, "[[a, b], [c, d]]"},
+		{`csv::Parse("a, b", {"trim_space": true})[0][1]`, "b"},
+		{`csv::Parse("a,b\n1,2,3\n")`, "ERROR: CSVError: record on line 2: wrong number of fields"},
+		{`csv::Parse("a,\"b\n")`, "ERROR: CSVError: parse error on line 1, column 6: extraneous or missing \" in quoted-field"},
+		{`csv::Parse("a", {"delimiter": ";;"})`, "ERROR: TypeError: Parse() option 'delimiter' must be a single character"},
+		{`csv::Read(path, {"delimiter": ";", "header": true})[1]["name"]`, "grace; h"},
+		{`let names = []; let n = csv::EachRow(path, fn(row, i) { names.append(row["name"]); i < 2 }, {"delimiter": ";", "header": true}); [n, names]`, "[2, [ada, grace; h]]"},
+		{`csv::EachRow(path + ".missing", fn(row, i) { true })`, "ERROR: FileNotFoundError: open " + path + ".missing: no such file or directory"},
+		{`csv::Stringify([["a", "b,c"], [1, "say \"hi\""]])`, "a,\"b,c\"\n1,\"say \"\"hi\"\"\"\n"},
+		{`csv::Stringify([{"b": 2, "a": 1}, {"a": 3, "c": true}])`, "a,b,c\n1,2,\n3,,true\n"},
+		{`csv::Stringify([{"name": "ada", "age": 36}], {"columns": ["name", "age"], "delimiter": ";", "quote_all": true})`, "\"name\";\"age\"\n\"ada\";\"36\"\n"},
+		{`let rows = csv::Parse(csv::Stringify([["x\ny", " z"]])); rows[0][0] == "x\ny"`, "true"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let csv = import("eso/csv"); let path = "` + path + `"; ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestTimeModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`time::Parse("2024-03-01T10:30:00Z")`, "2024-03-01T10:30:00Z"},
+		{`time::Parse("01/02/2024 08:00", "01/02/2006 15:04").format("RFC1123")`, "Tue, 02 Jan 2024 08:00:00 UTC"},
+		{`time::Parse("2024-03-01", "DateOnly").add_date(0, 1, 0).format("DateOnly")`, "2024-04-01"},
+		{`time::Parse("not a date")`, `ERROR: ValueError: cannot parse "not a date" as ISO, expected "2006" at "not a date"`},
+		{`time::Parse("2024-13-01", "DateOnly")`, `ERROR: ValueError: cannot parse "2024-13-01" as DateOnly: month out of range`},
+		{`let d = time::Date(2024, 2, 28, 23, 0, 0); [d.year(), d.month(), d.day(), d.hour(), d.weekday()]`, "[2024, 2, 28, 23, Wednesday]"},
+		{`time::Date(2024, 2, 28, 23)`, "ERROR: TypeError: Date() expects hour, minute and second together"},
+		{`time::Date(2024, 1, 1, 12, 0, 0, "UTC").in("America/New_York").format("DateTime")`, "2024-01-01 07:00:00"},
+		{`time::Date(2024, 7, 1, 12, 0, 0, "Europe/Paris").utc().hour()`, "10"},
+		{`time::Now("Mars/Olympus")`, "ERROR: ValueError: unknown time zone 'Mars/Olympus'"},
+		{`time::Unix(1700000000)`, "2023-11-14T22:13:20Z"},
+		{`time::UnixMilli(1500).unix_milli()`, "1500"},
+		{`time::Date(2024, 1, 1) + time::Duration("36h")`, "2024-01-02T12:00:00Z"},
+		{`time::Date(2024, 1, 1) - time::Hour * 2`, "2023-12-31T22:00:00Z"},
+		{`time::Date(2024, 3, 1) - time::Date(2024, 2, 1)`, "696h0m0s"},
+		{`(time::Date(2024, 3, 1) - time::Date(2024, 2, 1)) / time::Hour`, "696.0"},
+		{`time::Date(2024, 1, 1) < time::Date(2024, 1, 2)`, "true"},
+		{`time::Date(2024, 1, 1, 12, 0, 0) == time::Date(2024, 1, 1, 13, 0, 0, "Europe/Paris")`, "true"},
+		{`time::Now() == "a"`, "false"},
+		{`time::Now() != "a"`, "true"},
+		{`time::Minute == 60`, "false"},
+		{`time::Date(2024, 1, 1) < "a"`, "ERROR: <test>:1:56: type mismatch: DATETIME < STRING"},
+		{`time::Duration("1h30m").minutes()`, "90.0"},
+		{`time::Duration(1500) + time::Second`, "2.5s"},
+		{`-time::Minute`, "-1m0s"},
+		{`time::Duration("1.7s").round("1s")`, "2s"},
+		{`time::Duration("soon")`, "ERROR: ValueError: invalid duration 'soon', expected e.g 1h30m, 250ms"},
+		{`time::Sleep(time::Millisecond); time::Since(time::Now()) >= time::Duration(0)`, "true"},
+		{`let json = import("eso/json"); json::Stringify({"at": time::Unix(0), "ttl": time::Minute})`, `{"at":"1970-01-01T00:00:00Z","ttl":"1m0s"}`},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let time = import("eso/time"); ` + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let t = spawn fn() { 1 + 2 }; t.await()`, "3"},
+		{`let add = fn(a, b) { a + b }; (spawn add(2, 3)).await()`, "5"},
+		{`let t = spawn fn() { 1 }; t.await(); [t.done(), t]`, "[true, <task done>]"},
+		{`spawn 5`, "ERROR: <test>:1:7: spawn expects a function or a call, got INTEGER"},
+		{`(spawn fn() { 1 + true }).await()`, "ERROR: <test>:1:18: type mismatch: INTEGER + BOOLEAN"},
+		{`(spawn fn(a) { a }).await()`, "ERROR: <test>:1:15: TypeError: function missing required argument 'a' (0 given)"},
+		{`(spawn fn() { let c = Channel(); c.recv() }).await(10)`, "ERROR: TimeoutError: task did not finish within 10ms"},
+		{`let ch = Channel(); spawn fn() { ch.send(42) }; ch.recv()`, "42"},
+		{`let jobs = Channel(10); let results = Channel(10);
+		  let worker = fn() { jobs.each(fn(n) { results.send(n * n) }) };
+		  let workers = [spawn worker(), spawn worker(), spawn worker()];
+		  let i = 1; when (i <= 5) { jobs.send(i); let i = i + 1; }
+		  jobs.close(); workers[0].await(); workers[1].await(); workers[2].await(); 
+		  let total = 0; let k = 0; when (k < 5) { let total = total + results.recv(); let k = k + 1; } total`, "55"},
+		{`let shared = 0; let tasks = []; let i = 0;
+		  when (i < 20) { tasks.append(spawn fn() { let shared = 1; shared }); let i = i + 1; }
+		  let n = 0; when (n < 20) { tasks[n].await(); let n = n + 1; } shared`, "0"},
+		{`let c = Channel(2); c.send(1); c.close(); [c.recv(), c.recv(), c.closed(), c.len(), c.cap()]`, "[1, null, true, 0, 2]"},
+		{`let c = Channel(1); c.close(); c.send(1)`, "ERROR: ChannelError: send on closed channel"},
+		{`let c = Channel(); c.close(); c.close()`, "ERROR: ChannelError: channel is already closed"},
+		{`Channel(-1)`, "ERROR: ValueError: Channel() size must not be negative, got -1"},
+		{`let a = Channel(1); let b = Channel(1); b.send("x"); let r = select([a, b]); [r["index"], r["value"], r["ok"]]`, "[1, x, true]"},
+		{`let a = Channel(); a.close(); let r = select([a]); [r["index"], r["value"], r["ok"]]`, "[0, null, false]"},
+		{`let c = Channel(1); let r = select([[c, 7]]); [r["index"], c.recv()]`, "[0, 7]"},
+		{`select([Channel()], 10)["index"]`, "-1"},
+		{`select([], 0)["index"]`, "-1"},
+		{`select([1])`, "ERROR: TypeError: select() case #1 must be a CHANNEL or [channel, value] got `INTEGER`"},
+		{`select([])`, "ERROR: ValueError: select() without cases or timeout would block forever"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestAsyncAwait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "note.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`async func add(a, b) { return a + b }; let t = add(1, 2); [type_of(t), await t]`, "[TASK, 3]"},
+		{`let twice = async fn(x) { x * 2 }; await twice(21)`, "42"},
+		{`await 5`, "5"},
+		{`async func fail() { 1 + true }; await fail()`, "ERROR: <test>:2:24: type mismatch: INTEGER + BOOLEAN"},
+		{`async func fail() { 1 + true }; let t = fail(); 7`, "7"},
+		{`async func inner() { 3 }; await spawn inner()`, "3"},
+		{`let out = async ` + "`echo ${path}`" + `; (await out)["stdout"]`, path + "\n"},
+		{`await ReadFileAsync(path)`, "hello"},
+		{`await task::All([ReadFileAsync(path), 2, task::Sleep(1)])`, "[hello, 2, null]"},
+		{`async func fail(n) { if (n > 1) { 1 + true } else { n } }; await task::All([fail(1), fail(2), fail(3)])`, "ERROR: <test>:2:38: type mismatch: INTEGER + BOOLEAN"},
+		{`task::All(5)`, "ERROR: TypeError: All() expected argument #1 to be `ARRAY` got `INTEGER`"},
+		{`async func slow() { time_sleep(200); "slow" }; await task::Race([slow(), task::Sleep(1)])`, "null"},
+		{`task::Race([])`, "ERROR: ValueError: Race() needs at least one task"},
+		{`let h = import("eso/http"); let tasks = [h::GetAsync(url + "/a"), h::GetAsync(url + "/b"), h::GetAsync(url + "/c"), h::GetAsync(url + "/d")];
+		  let pages = await task::All(tasks); [pages[0].text(), pages[3].text()]`, "[/a, /d]"},
+	}
+
+	for _, test := range tests {
+		start := time.Now()
+		evaluated := testEval(`let task = import("eso/async"); let url = "` + server.URL + `"; let path = "` + path + `"` + "\n" + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+		// four requests of 150ms each must overlap
+		if elapsed := time.Since(start); elapsed > 450*time.Millisecond {
+			t.Errorf("%q took %s, tasks did not run concurrently", test.input, elapsed)
+		}
+	}
+}
+
+func TestModuleImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app/main.eso":      `let util = import("./lib/util"); let Greeting = util::Greet("eso");`,
+		"app/lib/util.eso":  `let shared = import("../../shared"); func Greet(name) { shared::Prefix + name }`,
+		"shared.eso":        `let Prefix = "hello "; let Items = [];`,
+		"cycle/a.eso":       `let b = import("./b"); let A = 1;`,
+		"cycle/b.eso":       `let a = import("./a"); let B = 2;`,
+		"broken/main.eso":   `let missing = import("./nope");`,
+		"broken/syntax.eso": `let = ;`,
+	}
+	for name, code := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	utils.AddPackage("acme/tools", dir)
+	defer delete(utils.Packages, "acme/tools")
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import(dir + "/app/main")::Greeting`, "hello eso"},
+		{`let a = import(dir + "/shared"); a::Items.append(1); let b = import(dir + "/app/../shared.eso"); b::Items`, "[1]"},
+		{`let tasks = [spawn fn() { import(dir + "/shared") }, spawn fn() { import(dir + "/shared") }]; tasks[0].await()::Items.append(2); tasks[1].await()::Items`, "[1, 2]"},
+		{`import(dir + "/broken/syntax")`, "ERROR: " + dir + "/broken/syntax.eso: Line 1 Column 6 - expected next token to be IDENT got =\n" +
+			dir + "/broken/syntax.eso Line 1 Column 6 - no prefix parse function for = found"},
+		{`import(dir + "/cycle/a")`, "ERROR: ImportError: circular import " + dir + "/cycle/a.eso -> " + dir + "/cycle/b.eso -> " + dir + "/cycle/a.eso"},
+		{`import(dir + "/broken/main")`, "ERROR: ImportError: no module named './nope'"},
+		{`import("./definitely_missing")`, "ERROR: ImportError: no module named './definitely_missing'"},
+		{"import \"eso/string\" as s\ns::Len(\"eso\")", "3"},
+		{`from "eso/math" import Abs, Max as biggest; [Abs(-2), biggest(1, 5)]`, "[2, 5]"},
+		{`import "eso/json" as j; j::Stringify([1])`, "[1]"},
+		{`from "eso/math" import Abs, helper`, "ERROR: <test>:2:36: ImportError: cannot import 'helper' from 'eso/math', only names starting with a capital letter are exported"},
+		{`from "eso/math" import Abs, Nope`, "ERROR: <test>:2:34: ImportError: cannot import 'Nope' from 'eso/math', it has no such export"},
+		{`from "eso/nothing" import X`, "ERROR: stdlib: nothing not found"},
+		{`import("acme/tools/shared")::Prefix + import("acme/tools/app/main")::Greeting`, "hello hello eso"},
+		{`import("acme/tools/nope")`, "ERROR: ImportError: no module named 'acme/tools/nope'"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(`let dir = "` + dir + `"` + "\n" + test.input)
+		if evaluated == nil || evaluated.Inspect() != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%+v", test.input, test.expected, evaluated)
+		}
+	}
+}
+
+func TestBigNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"type_of(9223372036854775807 + 1)", "BIGINT"},
+		{"type_of(9223372036854775807 + 1 - 1)", "INTEGER"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"99999999999999999999 / 3", "33333333333333333333"},
+		{"99999999999999999999 % 10", "9"},
+		{"99999999999999999999 > 1", "true"},
+		{"99999999999999999999 == 99999999999999999999", "true"},
+		{"99999999999999999999 + 0.5", "1e+20"},
+		{"-99999999999999999999", "-99999999999999999999"},
+		{`{99999999999999999999: "big"}[99999999999999999999]`, "big"},
+		{`BigInt("0xff")`, "255"},
+		{`type_of(BigInt(5))`, "INTEGER"},
+		{`(-9223372036854775807 - 1).abs()`, "9223372036854775808"},
+		{`-(-9223372036854775807 - 1)`, "9223372036854775808"},
+		{`(-5).abs()`, "5"},
+		{`{5: "a"}[BigInt(5)]`, "a"},
+		{`{BigInt("99999999999999999999"): "big"}[BigInt("99999999999999999999")]`, "big"},
+		{`type_of(BigInt("123456789012345678901234567890"))`, "BIGINT"},
+		{`Decimal("0.1") + Decimal("0.2")`, "0.3"},
+		{`Decimal("0.1") + Decimal("0.2") == Decimal("0.3")`, "true"},
+		{`Decimal("19.99") * 3`, "59.97"},
+		{`Decimal("10.00") / 4`, "2.50"},
+		{`Decimal(1) / Decimal(3)`, "0.3333333333333333333333333333"},
+		{`Decimal("7.5") % 2`, "1.5"},
+		{`Decimal("1.50") > 1`, "true"},
+		{`Decimal("2.675").round(2)`, "2.68"},
+		{`Decimal("2.665").round(2)`, "2.66"},
+		{`-Decimal("1.5")`, "-1.5"},
+		{`Decimal(0.1)`, "0.1"},
+		{`{Decimal("1.0"): "one"}[Decimal("1.00")]`, "one"},
+		{`type_of(Decimal("1"))`, "DECIMAL"},
+		{`Decimal("1") + 0.5`, FILE + ":1:15: type mismatch: DECIMAL + FLOAT"},
+		{`Decimal("1e90000000")`, "ValueError: decimal exponent of \"1e90000000\" out of range [-10000, 10000]"},
+		{`Decimal("1.5").round(4294967298)`, "ValueError: Decimal.round() places must be at most 10000, got 4294967298"},
+		{`Decimal("1.5").to_string(2147483648)`, "ValueError: Decimal.to_string() places must be at most 10000, got 2147483648"},
+	}
+
+	for _, test := range tests {
+		evaluated := testEval(test.input)
+		if evaluated == nil {
+			t.Errorf("no result for %q", test.input)
+			continue
+		}
+		got := evaluated.Inspect()
+		if errObj, ok := evaluated.(*object.Error); ok {
+			got = errObj.Message
+		}
+		if got != test.expected {
+			t.Errorf("wrong result for %q. expected=%q, got=%q", test.input, test.expected, got)
+		}
+	}
+}
+
 func testBooleanObject(t *testing.T, evaluated object.Object, b bool) bool {
 	boolean, ok := evaluated.(*object.Boolean)
 	if !ok {
@@ -0,0 +1,68 @@
+package evaluator
+
+import (
+	"context"
+	"esolang/lang-esolang/lexer"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/parser"
+	"strings"
+	"testing"
+	"time"
+)
+
+// FuzzEval drives random programs through the lexer, the parser and Eval, a
+// Go panic anywhere in them is a bug. Run it with go test -fuzz=FuzzEval ./evaluator.
+func FuzzEval(f *testing.F) {
+	seeds := []string{
+		`let add = fn(a, b) { a + b }; add(1)`,
+		`(spawn fn(a) { a }).await()`,
+		`Http()`,
+		`Http(1, 2)`,
+		`"abc".`,
+		`[1, 2].foo(`,
+		`import("noslash")`,
+		`from "eso" import X`,
+		`let h = {"a": [1, 2]}; h["a"][5]`,
+		`func f(n) { if (n < 1) { return 0 }; f(n - 1) }; f(10)`,
+		`when (true) { }`,
+		`let s = "ab"; s *= 40`,
+		`re"(a)".captures("b")`,
+		`123456789012345678901234567890 * 2`,
+		"`echo ${x}`",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		p := parser.New(lexer.New(FILE, input))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			return
+		}
+
+		// a sandbox with small limits, so programs cannot touch the host or run away
+		rt := object.NewRuntime(nil, nil, nil)
+		rt.Limits = object.Limits{MaxSteps: 10000, MaxCallDepth: 100, MaxCollectionSize: 1 << 16}
+		rt.Permissions = &object.Permissions{}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		rt.Start(ctx)
+		env := object.NewEnvironment()
+		env.SetRuntime(rt)
+
+		// a program blocked outside of the evaluator, e.g. receiving from a
+		// channel nothing sends to, does not see the context
+		done := make(chan object.Object, 1)
+		go func() {
+			done <- Eval(program, env)
+		}()
+		select {
+		case evaluated := <-done:
+			if err, ok := evaluated.(*object.Error); ok && strings.Contains(err.Message, "InternalError") {
+				t.Errorf("%q panicked: %s", input, err.Message)
+			}
+		case <-time.After(2 * time.Second):
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package evaluator
+
+import (
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/builtins"
+	"esolang/lang-esolang/lexer"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/parser"
+	"esolang/lang-esolang/utils"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ResetModules empties the module cache of the default runtime, the next import
+// of each module evaluates it again.
+func ResetModules() {
+	object.DefaultRuntime.Modules.Reset()
+}
+
+// Module returns the exported names of the module name imported from the file
+// importer, under the runtime rt.
+func Module(rt *object.Runtime, importer, name string) object.Object {
+	key := name
+	if !utils.IsBuiltinModule(name) {
+		if rt.FS != nil {
+			key = resolveFSModule(rt, importer, name)
+		} else {
+			importer = moduleKey(importer)
+			dir, _ := os.Getwd()
+			if filepath.IsAbs(importer) {
+				dir = filepath.Dir(importer)
+			}
+			key = utils.ResolveModule(name, dir)
+		}
+		if key == "" {
+			return &object.Error{Message: fmt.Sprintf("ImportError: no module named '%s'", name)}
+		}
+		// modules of rt.FS were checked when resolved, bundled ones are part of the program
+		if _, bundled := utils.Bundled[key]; rt.FS == nil && !bundled {
+			if err := rt.CheckRead(key); err != nil {
+				return &object.Error{Message: fmt.Sprintf("PermissionError: %s", err)}
+			}
+		}
+	}
+
+	attrs, chain := rt.Modules.Load(importer, key, func() object.Object {
+		return loadModule(rt, name, key)
+	})
+	if chain != nil {
+		for i, key := range chain {
+			chain[i] = displayModule(key)
+		}
+		return &object.Error{Message: fmt.Sprintf("ImportError: circular import %s", strings.Join(chain, " -> "))}
+	}
+	return attrs
+}
+
+// resolveFSModule returns the file of rt.FS for name, relative names are looked
+// up from the importing file's directory and others from the root. It returns
+// "" when there is no such module.
+func resolveFSModule(rt *object.Runtime, importer, name string) string {
+	filename := name
+	if utils.IsRelativeModule(name) {
+		filename = path.Join(path.Dir(filepath.ToSlash(importer)), name)
+	}
+	if !strings.HasSuffix(filename, ".eso") {
+		filename += ".eso"
+	}
+	filename = strings.TrimPrefix(path.Clean("/"+filename), "/")
+	if info, err := rt.Stat(filename); err != nil || info.IsDir() {
+		return ""
+	}
+	return filename
+}
+
+// evalImportStatement binds the module, or the requested exported names, in env.
+func evalImportStatement(node *ast.ImportStatement, env *object.Environment) object.Object {
+	name := node.Path.Value
+	attrs := Module(env.Runtime(), node.Token.FileName, name)
+	if isError(attrs) {
+		return attrs
+	}
+	if node.Alias != nil {
+		env.Set(node.Alias.Value, &object.Module{Name: name, Attrs: attrs})
+		return nil
+	}
+
+	exported := attrs.(*object.Hash).Pairs
+	values := make([]object.Object, len(node.Names))
+	for i, imported := range node.Names {
+		tok := imported.Name.Token
+		if !unicode.IsUpper(rune(imported.Name.Value[0])) {
+			return newError(tok.FileName, tok.Line, tok.Column, "ImportError: cannot import '%s' from '%s', only names starting with a capital letter are exported", imported.Name.Value, name)
+		}
+		key := &object.String{Value: imported.Name.Value}
+		pair, ok := exported[key.HashKey()]
+		if !ok {
+			return newError(tok.FileName, tok.Line, tok.Column, "ImportError: cannot import '%s' from '%s', it has no such export", imported.Name.Value, name)
+		}
+		values[i] = pair.Value
+	}
+	// bind nothing unless every name was found
+	for i, imported := range node.Names {
+		env.Set(imported.Alias.Value, values[i])
+	}
+	return nil
+}
+
+// moduleKey normalizes the file name tokens carry to the key of the module cache.
+func moduleKey(fileName string) string {
+	if utils.IsBuiltinModule(fileName) || !utils.Exists(fileName) {
+		return fileName
+	}
+	if abs, err := filepath.Abs(fileName); err == nil {
+		return abs
+	}
+	return fileName
+}
+
+// displayModule shortens a module path relative to the working directory for messages.
+func displayModule(key string) string {
+	if cwd, err := os.Getwd(); err == nil && filepath.IsAbs(key) {
+		if rel, err := filepath.Rel(cwd, key); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return key
+}
+
+// loadModule evaluates a module in a fresh environment of rt and returns its exported names.
+func loadModule(rt *object.Runtime, name, filename string) object.Object {
+	code := ""
+	if utils.IsBuiltinModule(name) {
+		// TODO: line numbers and column numbers for built-in modules
+		// errors should be the node's line and column numbers instead
+		_, moduleName, _ := strings.Cut(name, "/")
+		moduleCode, err := builtins.GetStdLib(moduleName)
+		if err != nil {
+			return &object.Error{Message: err.Error()}
+		}
+		code = moduleCode
+	} else {
+		var b []byte
+		var err error
+		if rt.FS != nil {
+			b, err = rt.ReadFile(filename)
+		} else {
+			b, err = utils.ReadModule(filename)
+		}
+		if err != nil {
+			return &object.Error{Message: fmt.Sprintf("IOError: error reading module '%s': %s", name, err)}
+		}
+		code = string(b)
+	}
+
+	// tokens carry the full path so the module's own relative imports resolve from its directory
+	l := lexer.New(filename, code)
+	p := parser.New(l)
+
+	module := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return &object.Error{Message: strings.Join(p.Errors(), "\n")}
+	}
+
+	env := object.NewEnvironment()
+	env.SetRuntime(rt)
+	if evaluated, ok := Eval(module, env).(*object.Error); ok {
+		return evaluated
+	}
+	return env.ExportedHash()
+}
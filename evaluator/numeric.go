@@ -0,0 +1,211 @@
+package evaluator
+
+import (
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/token"
+	"math"
+	"math/big"
+)
+
+// infixToken returns the token of an infix or assign node, used to locate errors.
+func infixToken[T InfixExpressions](node T) token.Token {
+	switch node := any(node).(type) {
+	case *ast.InfixExpression:
+		return node.Token
+	case *ast.AssignStatement:
+		return node.Token
+	}
+	return token.Token{}
+}
+
+// addIntegers adds two integers, promoting the result to a BigInt on overflow.
+func addIntegers(a, b int64) object.Object {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return object.NewBigInt(new(big.Int).Add(big.NewInt(a), big.NewInt(b)))
+	}
+	return &object.Integer{Value: sum}
+}
+
+// subtractIntegers subtracts two integers, promoting the result to a BigInt on overflow.
+func subtractIntegers(a, b int64) object.Object {
+	difference := a - b
+	if (b > 0 && difference > a) || (b < 0 && difference < a) {
+		return object.NewBigInt(new(big.Int).Sub(big.NewInt(a), big.NewInt(b)))
+	}
+	return &object.Integer{Value: difference}
+}
+
+// multiplyIntegers multiplies two integers, promoting the result to a BigInt on overflow.
+func multiplyIntegers(a, b int64) object.Object {
+	if a == 0 || b == 0 {
+		return &object.Integer{Value: 0}
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return object.NewBigInt(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)))
+	}
+	return &object.Integer{Value: product}
+}
+
+// divideIntegers divides two integers, the only overflowing case being MinInt64 / -1.
+func divideIntegers(a, b int64) object.Object {
+	if a == math.MinInt64 && b == -1 {
+		return object.NewBigInt(new(big.Int).Neg(big.NewInt(a)))
+	}
+	return &object.Integer{Value: a / b}
+}
+
+// powerIntegers raises a to the power of b exactly, a negative exponent yields a Float.
+func powerIntegers(a, b int64) object.Object {
+	if b < 0 {
+		return &object.Float{Value: math.Pow(float64(a), float64(b))}
+	}
+	return object.NewBigInt(new(big.Int).Exp(big.NewInt(a), big.NewInt(b), nil))
+}
+
+func isBigNumber(obj object.Object) bool {
+	return obj.Type() == object.BIGINT_OBJ || obj.Type() == object.DECIMAL_OBJ
+}
+
+func isNumber(obj object.Object) bool {
+	switch obj.Type() {
+	case object.INTEGER_OBJ, object.FLOAT_OBJ, object.BIGINT_OBJ, object.DECIMAL_OBJ:
+		return true
+	}
+	return false
+}
+
+// isBigNumberOperation reports whether an infix operation involves a BigInt or a Decimal
+// together with another number it can be combined with. Decimals do not mix with Floats
+// since that would silently lose the exactness a Decimal is used for.
+func isBigNumberOperation(left, right object.Object) bool {
+	if !isBigNumber(left) && !isBigNumber(right) {
+		return false
+	}
+	if !isNumber(left) || !isNumber(right) {
+		return false
+	}
+	if left.Type() == object.DECIMAL_OBJ && right.Type() == object.FLOAT_OBJ {
+		return false
+	}
+	if left.Type() == object.FLOAT_OBJ && right.Type() == object.DECIMAL_OBJ {
+		return false
+	}
+	return true
+}
+
+// arithmeticOperator maps compound assignment operators onto their arithmetic operator.
+func arithmeticOperator(operator string) string {
+	switch operator {
+	case "+=":
+		return "+"
+	case "-=":
+		return "-"
+	case "*=":
+		return "*"
+	case "is":
+		return "=="
+	case "is_not":
+		return "!="
+	}
+	return operator
+}
+
+// evalBigNumberInfixExpression evaluates operations involving BigInts and Decimals.
+func evalBigNumberInfixExpression[T InfixExpressions](node T, operator string, left, right object.Object) object.Object {
+	if left.Type() == object.DECIMAL_OBJ || right.Type() == object.DECIMAL_OBJ {
+		return evalDecimalInfixExpression(node, operator, left, right)
+	}
+	if left.Type() == object.FLOAT_OBJ || right.Type() == object.FLOAT_OBJ {
+		return evalFloatInfixExpression(node, operator, bigIntToFloat(left), bigIntToFloat(right))
+	}
+	return evalBigIntInfixExpression(node, operator, left, right)
+}
+
+func bigIntToFloat(obj object.Object) object.Object {
+	if b, ok := obj.(*object.BigInt); ok {
+		f, _ := new(big.Float).SetInt(b.Value).Float64()
+		return &object.Float{Value: f}
+	}
+	return obj
+}
+
+func evalBigIntInfixExpression[T InfixExpressions](node T, operator string, left, right object.Object) object.Object {
+	tok := infixToken(node)
+	leftValue, _ := object.ToBigInt(left)
+	rightValue, _ := object.ToBigInt(right)
+
+	switch arithmeticOperator(operator) {
+	case "+":
+		return object.NewBigInt(new(big.Int).Add(leftValue, rightValue))
+	case "-":
+		return object.NewBigInt(new(big.Int).Sub(leftValue, rightValue))
+	case "*":
+		return object.NewBigInt(new(big.Int).Mul(leftValue, rightValue))
+	case "/":
+		if rightValue.Sign() == 0 {
+			return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+		}
+		return object.NewBigInt(new(big.Int).Quo(leftValue, rightValue))
+	case "%":
+		if rightValue.Sign() == 0 {
+			return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+		}
+		return object.NewBigInt(new(big.Int).Rem(leftValue, rightValue))
+	case "<":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) != 0)
+	default:
+		return newError(tok.FileName, tok.Line, tok.Column, "unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func evalDecimalInfixExpression[T InfixExpressions](node T, operator string, left, right object.Object) object.Object {
+	tok := infixToken(node)
+	leftValue, _ := object.ToDecimal(left)
+	rightValue, _ := object.ToDecimal(right)
+
+	switch arithmeticOperator(operator) {
+	case "+":
+		return leftValue.Add(rightValue)
+	case "-":
+		return leftValue.Sub(rightValue)
+	case "*":
+		return leftValue.Mul(rightValue)
+	case "/":
+		if rightValue.IsZero() {
+			return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+		}
+		return leftValue.Quo(rightValue)
+	case "%":
+		if rightValue.IsZero() {
+			return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+		}
+		return leftValue.Rem(rightValue)
+	case "<":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftValue.Cmp(rightValue) != 0)
+	default:
+		return newError(tok.FileName, tok.Line, tok.Column, "unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
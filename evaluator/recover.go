@@ -0,0 +1,44 @@
+package evaluator
+
+import (
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/token"
+	"fmt"
+	"reflect"
+)
+
+// evalStatement evaluates a statement of a program or a block. A Go panic while
+// evaluating it, a bug of the interpreter or of a builtin, becomes an
+// InternalError located at the innermost statement instead of crashing the process.
+func evalStatement(statement ast.Statement, env *object.Environment) (result object.Object) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = panicError(statement, r)
+		}
+	}()
+	return Eval(statement, env)
+}
+
+// panicError is the error raised for a panic recovered while evaluating node,
+// node may be nil when there is no node to locate it at.
+func panicError(node ast.Node, r any) *object.Error {
+	if tok, ok := nodeToken(node); ok {
+		return newError(tok.FileName, tok.Line, tok.Column, "InternalError: %v", r)
+	}
+	return &object.Error{Message: fmt.Sprintf("InternalError: %v", r)}
+}
+
+// nodeToken returns the token of node, every node but the program has one.
+func nodeToken(node ast.Node) (token.Token, bool) {
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return token.Token{}, false
+	}
+	field := v.Elem().FieldByName("Token")
+	if !field.IsValid() {
+		return token.Token{}, false
+	}
+	tok, ok := field.Interface().(token.Token)
+	return tok, ok
+}
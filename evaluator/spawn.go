@@ -0,0 +1,56 @@
+package evaluator
+
+import (
+	"esolang/lang-esolang/ast"
+	"esolang/lang-esolang/object"
+)
+
+// evalSpawnExpression starts a task. For `spawn work(a, b)` the function and its
+// arguments are evaluated right away and only the call runs concurrently, a
+// command literal runs as a whole, any other operand must evaluate to a
+// function taking no arguments.
+func evalSpawnExpression(node *ast.SpawnExpression, env *object.Environment) object.Object {
+	if command, ok := node.Task.(*ast.BacktickLiteral); ok {
+		return object.NewTask(func() object.Object {
+			return backTickOperation(command, env)
+		})
+	}
+	if call, ok := node.Task.(*ast.CallExpression); ok {
+		function := Eval(call.Function, env)
+		if isError(function) {
+			return function
+		}
+		args := evalExpressions(call.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
+		return object.NewTask(func() object.Object {
+			return applyFunction(call, function, args, env.Runtime())
+		})
+	}
+
+	function := Eval(node.Task, env)
+	if isError(function) {
+		return function
+	}
+	switch function.(type) {
+	case *object.Function, *object.Builtin:
+		return object.NewTask(func() object.Object {
+			return Call(env.Runtime(), function)
+		})
+	}
+	return newError(node.Token.FileName, node.Token.Line, node.Token.Column, "spawn expects a function or a call, got %s", function.Type())
+}
+
+// evalAwaitExpression waits for a task and gives its result, errors included.
+// Awaiting anything else gives the value back unchanged.
+func evalAwaitExpression(node *ast.AwaitExpression, env *object.Environment) object.Object {
+	value := Eval(node.Value, env)
+	if isError(value) {
+		return value
+	}
+	if task, ok := value.(*object.Task); ok {
+		return task.Wait(env.Runtime())
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+package evaluator
+
+import (
+	"esolang/lang-esolang/object"
+	"math"
+	"time"
+)
+
+// isTimeOperation reports whether an infix operation involves a DateTime or a Duration,
+// logical operators are left to the generic truthiness rules and so is equality
+// with another type, which is false like for any other value.
+func isTimeOperation(operator string, left, right object.Object) bool {
+	isTime := func(obj object.Object) bool {
+		return obj.Type() == object.DATETIME_OBJ || obj.Type() == object.DURATION_OBJ
+	}
+	switch operator {
+	case "&&", "and", "||", "or", "-|":
+		return false
+	case "==", "!=":
+		return left.Type() == right.Type() && isTime(left)
+	}
+	return isTime(left) || isTime(right)
+}
+
+func compareTimes(operator string, cmp int) (object.Object, bool) {
+	switch operator {
+	case "<":
+		return nativeBoolToBooleanObject(cmp < 0), true
+	case ">":
+		return nativeBoolToBooleanObject(cmp > 0), true
+	case "<=":
+		return nativeBoolToBooleanObject(cmp <= 0), true
+	case ">=":
+		return nativeBoolToBooleanObject(cmp >= 0), true
+	case "==":
+		return nativeBoolToBooleanObject(cmp == 0), true
+	case "!=":
+		return nativeBoolToBooleanObject(cmp != 0), true
+	}
+	return nil, false
+}
+
+// evalTimeInfixExpression implements date arithmetic and comparisons:
+//
+//	DateTime ± Duration -> DateTime    DateTime - DateTime -> Duration
+//	Duration ± Duration -> Duration    Duration * number, Duration / number -> Duration
+//	Duration / Duration -> Float       DateTime and Duration compare with < > <= >= == !=
+func evalTimeInfixExpression[T InfixExpressions](node T, operator string, left, right object.Object) object.Object {
+	tok := infixToken(node)
+	op := arithmeticOperator(operator)
+	unknown := func() object.Object {
+		if left.Type() != right.Type() && op != "+" && op != "-" && op != "*" && op != "/" {
+			return newError(tok.FileName, tok.Line, tok.Column, "type mismatch: %s %s %s", left.Type(), operator, right.Type())
+		}
+		return newError(tok.FileName, tok.Line, tok.Column, "unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	switch l := left.(type) {
+	case *object.DateTime:
+		switch r := right.(type) {
+		case *object.DateTime:
+			if op == "-" {
+				return &object.Duration{Value: l.Value.Sub(r.Value)}
+			}
+			if result, ok := compareTimes(op, l.Value.Compare(r.Value)); ok {
+				return result
+			}
+		case *object.Duration:
+			switch op {
+			case "+":
+				return &object.DateTime{Value: l.Value.Add(r.Value)}
+			case "-":
+				return &object.DateTime{Value: l.Value.Add(-r.Value)}
+			}
+		}
+
+	case *object.Duration:
+		switch r := right.(type) {
+		case *object.Duration:
+			switch op {
+			case "+":
+				return &object.Duration{Value: l.Value + r.Value}
+			case "-":
+				return &object.Duration{Value: l.Value - r.Value}
+			case "/":
+				if r.Value == 0 {
+					return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+				}
+				return &object.Float{Value: float64(l.Value) / float64(r.Value)}
+			}
+			cmp := 0
+			if l.Value < r.Value {
+				cmp = -1
+			} else if l.Value > r.Value {
+				cmp = 1
+			}
+			if result, ok := compareTimes(op, cmp); ok {
+				return result
+			}
+		case *object.DateTime:
+			if op == "+" {
+				return &object.DateTime{Value: r.Value.Add(l.Value)}
+			}
+		case *object.Integer, *object.Float:
+			return scaleDuration(node, op, l.Value, toFloat64(r), unknown)
+		}
+
+	case *object.Integer, *object.Float:
+		if r, ok := right.(*object.Duration); ok && op == "*" {
+			return scaleDuration(node, op, r.Value, toFloat64(l), unknown)
+		}
+	}
+	return unknown()
+}
+
+func scaleDuration[T InfixExpressions](node T, op string, d time.Duration, factor float64, unknown func() object.Object) object.Object {
+	tok := infixToken(node)
+	var scaled float64
+	switch op {
+	case "*":
+		scaled = float64(d) * factor
+	case "/":
+		if factor == 0 {
+			return newError(tok.FileName, tok.Line, tok.Column, "Can't divide by zero")
+		}
+		scaled = float64(d) / factor
+	default:
+		return unknown()
+	}
+	if math.IsNaN(scaled) || scaled > math.MaxInt64 || scaled < math.MinInt64 {
+		return newError(tok.FileName, tok.Line, tok.Column, "OverflowError: duration out of range")
+	}
+	return &object.Duration{Value: time.Duration(math.Round(scaled))}
+}
+
+func toFloat64(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.Float:
+		return obj.Value
+	}
+	return 0
+}
@@ -81,6 +81,19 @@ func (L *Lexer) NextToken() token.Token {
 			tok.Line = L.line
 			tok.Column = L.column
 			tok.FileName = L.fileName
+
+			// re"..." is a regular expression literal
+			if tok.Literal == "re" && L.char == '"' {
+				pattern, err := L.readRawString('"')
+				if err != nil {
+					tok.Literal = err.Error()
+					tok.Type = token.ILLEGAL
+				} else {
+					tok.Literal = pattern
+					tok.Type = token.REGEX
+				}
+				L.readChar()
+			}
 			return tok
 		} else if isDigit(L.char) {
 			// tok.Type = token.INT
@@ -114,10 +127,11 @@ func (L *Lexer) NextToken() token.Token {
 		}
 
 	case '`':
-		str, err := L.readString('`')
+		// commands are reported at their opening backtick
 		tok.FileName = L.fileName
 		tok.Line = L.line
 		tok.Column = L.column
+		str, err := L.readString('`')
 		if err != nil {
 			tok.Literal = err.Error()
 			tok.Type = token.ILLEGAL
@@ -314,6 +328,27 @@ func (L *Lexer) readString(delim rune) (string, error) {
 	return out, nil
 }
 
+// readRawString reads up to the closing delimiter without interpreting escape
+// sequences, so that patterns such as re"\d+" reach the regexp engine intact.
+// An escaped delimiter does not terminate the string.
+func (L *Lexer) readRawString(delim byte) (string, error) {
+	position := L.position + 1
+	for {
+		L.readChar()
+		if L.char == 0 {
+			return "", errors.New("unterminated regular expression")
+		}
+		if L.char == '\\' && L.peekChar() == delim {
+			L.readChar()
+			continue
+		}
+		if L.char == delim {
+			break
+		}
+	}
+	return L.input[position:L.position], nil
+}
+
 func (L *Lexer) skipComment() {
 	for L.char != '\n' && L.char != 0 {
 		L.readChar()
@@ -347,7 +382,8 @@ func (L *Lexer) readIdentifier() string {
 	position := L.position
 	rposition := L.readPosition
 
-	for isLetter(L.char) {
+	// the first character is always a letter, digits may follow e.g. `log2`
+	for isLetter(L.char) || isDigit(L.char) {
 		id += string(L.char)
 		L.readChar()
 	}
@@ -215,3 +215,33 @@ blah.blah.blah
 		}
 	}
 }
+
+func TestRegexLiteral(t *testing.T) {
+	input := `let r = re"\d+\"x";
+re + "s"`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "r"},
+		{token.ASSIGN, "="},
+		{token.REGEX, `\d+\"x`},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "re"},
+		{token.PLUS, "+"},
+		{token.STRING, "s"},
+		{token.EOF, ""},
+	}
+	l := New(FILE, input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong, expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - Literal wrong, expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
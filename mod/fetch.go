@@ -0,0 +1,300 @@
+package mod
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CacheDir is where git dependencies are kept, one directory per name and
+// commit. It is $ESOLANG_CACHE when set.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("ESOLANG_CACHE"); dir != "" {
+		return filepath.Abs(dir)
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "esolang", "mod"), nil
+}
+
+func cachedPackage(cache, name, commit string) string {
+	return filepath.Join(cache, name+"@"+commit)
+}
+
+// GetOptions changes how Get resolves dependencies.
+type GetOptions struct {
+	// Update resolves git versions again instead of using the commits in the lockfile.
+	Update bool
+	// Log receives a line for each dependency fetched, nil discards them.
+	Log io.Writer
+}
+
+// Get makes every dependency of the manifest, and the dependencies of those,
+// available locally: git repositories are cloned into the cache at the commit
+// pinned by the lockfile, or the requested version when not locked yet, and
+// local paths are checked. It returns the new lock, which the caller writes.
+func Get(m *Manifest, opts GetOptions) (*Lock, error) {
+	if opts.Log == nil {
+		opts.Log = io.Discard
+	}
+	cache, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	previous, err := ReadLock(filepath.Join(m.Dir(), LockFile))
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		req      *Require
+		from     string // directory of the manifest requiring it
+		required string // package requiring it, for messages
+	}
+	queue := []pending{}
+	for _, r := range m.Requires {
+		queue = append(queue, pending{r, m.Dir(), m.Package})
+	}
+
+	lock := &Lock{Entries: map[string]*Locked{}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		r := p.req
+
+		source := r.Source
+		if r.IsLocal() {
+			dir, err := localDir(p.from, r.Source)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %s", p.required, r.Name, err)
+			}
+			// local paths are recorded relative to the root manifest
+			if source, err = filepath.Rel(m.Dir(), dir); err != nil {
+				source = dir
+			}
+			if !strings.HasPrefix(source, ".") && !filepath.IsAbs(source) {
+				source = "./" + source
+			}
+		}
+		version := r.Version
+		if version == "" {
+			version = "-"
+		}
+
+		if existing, ok := lock.Entries[r.Name]; ok {
+			if existing.Source != source || existing.Version != version {
+				return nil, fmt.Errorf("%s requires %s %s %s, but it is already required as %s %s", p.required, r.Name, source, version, existing.Source, existing.Version)
+			}
+			continue
+		}
+
+		entry := &Locked{Name: r.Name, Source: source, Version: version}
+		var dir string
+		if r.IsLocal() {
+			dir, _ = localDir(p.from, r.Source)
+			entry.Commit = "local"
+		} else {
+			rev := r.Version
+			old, locked := previous.Entries[r.Name]
+			locked = locked && !opts.Update && old.Source == source && old.Version == version
+			if locked {
+				rev = old.Commit
+			}
+			if entry.Commit, dir, err = fetchGit(cache, r.Name, r.Source, rev, opts.Log); err != nil {
+				return nil, fmt.Errorf("%s: %s", r.Name, err)
+			}
+			if locked && old.Sum != "" {
+				sum, err := HashDir(dir)
+				if err != nil {
+					return nil, err
+				}
+				if sum != old.Sum {
+					return nil, fmt.Errorf("%s: checksum mismatch for %s, expected %s got %s", r.Name, dir, old.Sum, sum)
+				}
+			}
+		}
+		if entry.Sum, err = HashDir(dir); err != nil {
+			return nil, err
+		}
+		lock.Entries[r.Name] = entry
+
+		if path := filepath.Join(dir, ManifestFile); fileExists(path) {
+			dep, err := ReadManifest(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, req := range dep.Requires {
+				queue = append(queue, pending{req, dir, dep.Package})
+			}
+		}
+	}
+	return lock, nil
+}
+
+// localDir resolves a local source against the directory of the manifest declaring it.
+func localDir(from, source string) (string, error) {
+	if strings.HasPrefix(source, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		source = filepath.Join(home, source[1:])
+	}
+	dir := source
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(from, source)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("local dependency %s is not a directory", source)
+	}
+	return filepath.Abs(dir)
+}
+
+// fetchGit clones source at rev, or the default branch when rev is empty, into
+// the cache and returns the commit and its directory. A commit already in the
+// cache is not fetched again.
+func fetchGit(cache, name, source, rev string, log io.Writer) (commit, dir string, err error) {
+	if isCommit(rev) {
+		if dir = cachedPackage(cache, name, rev); fileExists(dir) {
+			return rev, dir, nil
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "esolang-get-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	fmt.Fprintln(log, strings.TrimSpace("get "+name+" "+source+" "+rev))
+	if _, err := git("", "clone", "--quiet", "--", source, tmp); err != nil {
+		return "", "", err
+	}
+	if rev != "" {
+		if _, err := git(tmp, "checkout", "--quiet", rev, "--"); err != nil {
+			return "", "", err
+		}
+	}
+	if commit, err = git(tmp, "rev-parse", "HEAD"); err != nil {
+		return "", "", err
+	}
+
+	dir = cachedPackage(cache, name, commit)
+	if fileExists(dir) {
+		return commit, dir, nil
+	}
+	// copy next to the final directory and rename, so an interrupted get leaves no partial package
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", "", err
+	}
+	partial, err := os.MkdirTemp(filepath.Dir(dir), ".partial-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(partial)
+	if err := copyDir(tmp, partial); err != nil {
+		return "", "", err
+	}
+	if err := os.Rename(partial, dir); err != nil {
+		return "", "", err
+	}
+	return commit, dir, nil
+}
+
+func isCommit(rev string) bool {
+	if len(rev) != 40 {
+		return false
+	}
+	for _, c := range rev {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Vendor copies every locked dependency into the vendor directory next to the
+// manifest, replacing what was there. Vendored packages are used before the cache.
+func Vendor(m *Manifest, lock *Lock) error {
+	cache, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	vendor := filepath.Join(m.Dir(), VendorDir)
+	if err := os.RemoveAll(vendor); err != nil {
+		return err
+	}
+	for _, entry := range lock.Entries {
+		src := entryDir(m.Dir(), cache, entry)
+		if err := copyDir(src, filepath.Join(vendor, entry.Name)); err != nil {
+			return fmt.Errorf("vendor %s: %s", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// entryDir is where a locked dependency lives outside of vendor.
+func entryDir(root, cache string, entry *Locked) string {
+	if entry.Commit == "local" {
+		if filepath.IsAbs(entry.Source) {
+			return entry.Source
+		}
+		return filepath.Join(root, entry.Source)
+	}
+	return cachedPackage(cache, entry.Name, entry.Commit)
+}
+
+// copyDir copies the files of src into dst, leaving out .git.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, b, info.Mode().Perm())
+	})
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,119 @@
+package mod
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Locked is a dependency pinned by the lockfile.
+type Locked struct {
+	Name    string
+	Source  string // as written in the manifest that required it
+	Version string // as requested, "-" when none was given
+	Commit  string // the resolved git commit, "local" for a local path
+	Sum     string // sha256 of the package files, see HashDir
+}
+
+// Lock is a parsed esolang.lock, one line per dependency, direct or not:
+//
+//	<name> <source> <version> <commit> <sum>
+type Lock struct {
+	Entries map[string]*Locked
+}
+
+const lockHeader = "# esolang.lock - generated by esolang get, do not edit\n"
+
+// ReadLock parses the lockfile at path, a missing lockfile is an empty lock.
+func ReadLock(path string) (*Lock, error) {
+	lock := &Lock{Entries: map[string]*Locked{}}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 5 || checkName(fields[0]) != nil || (fields[3] != "local" && !isCommit(fields[3])) {
+			return nil, fmt.Errorf("%s:%d: malformed lock entry", path, i+1)
+		}
+		lock.Entries[fields[0]] = &Locked{Name: fields[0], Source: fields[1], Version: fields[2], Commit: fields[3], Sum: fields[4]}
+	}
+	return lock, nil
+}
+
+// String renders the lock sorted by name, so it diffs cleanly.
+func (l *Lock) String() string {
+	names := make([]string, 0, len(l.Entries))
+	for name := range l.Entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out strings.Builder
+	out.WriteString(lockHeader)
+	for _, name := range names {
+		e := l.Entries[name]
+		fmt.Fprintf(&out, "%s %s %s %s %s\n", e.Name, e.Source, e.Version, e.Commit, e.Sum)
+	}
+	return out.String()
+}
+
+// Write saves the lock to path.
+func (l *Lock) Write(path string) error {
+	return os.WriteFile(path, []byte(l.String()), 0644)
+}
+
+// HashDir returns a checksum over the relative path and contents of every
+// file in dir, skipping .git and vendor directories.
+func HashDir(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != dir && (d.Name() == ".git" || d.Name() == VendorDir) {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, path := range files {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
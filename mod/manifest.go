@@ -0,0 +1,249 @@
+/*
+Package mod reads esolang.mod manifests and their esolang.lock lockfiles, and
+fetches the dependencies they declare into the module cache or a vendor directory.
+
+A manifest names the package and its dependencies, each by the name scripts
+import it under, a git URL or a local path, and for git an optional tag,
+branch or commit:
+
+	package acme/app
+
+	require colors https://github.com/acme/colors.git v1.2.0
+	require (
+		strutil ../strutil
+	)
+*/
+package mod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ManifestFile = "esolang.mod"
+	LockFile     = "esolang.lock"
+	VendorDir    = "vendor"
+)
+
+// Require is a dependency declared in a manifest.
+type Require struct {
+	Name    string // import prefix, e.g "colors" for import("colors/palette")
+	Source  string // git URL or local path
+	Version string // git tag, branch or commit, empty for the default branch or a local path
+	line    int    // index in Manifest.lines
+}
+
+// IsLocal reports whether the dependency is a path on this machine rather than a git repository.
+func (r *Require) IsLocal() bool {
+	return isLocalSource(r.Source)
+}
+
+func isLocalSource(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
+
+// Manifest is a parsed esolang.mod. The original lines are kept so updating
+// a requirement leaves comments and layout alone.
+type Manifest struct {
+	Path     string // the esolang.mod file
+	Package  string
+	Requires []*Require
+	lines    []string
+}
+
+// Dir is the directory of the manifest, local sources are relative to it.
+func (m *Manifest) Dir() string {
+	return filepath.Dir(m.Path)
+}
+
+// FindManifest looks for esolang.mod in dir and its parents, returning "" when there is none.
+func FindManifest(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ManifestFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ReadManifest parses the manifest at path.
+func ReadManifest(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(abs, string(b))
+}
+
+// ParseManifest parses manifest text, path is used for messages and to resolve local sources.
+func ParseManifest(path, text string) (*Manifest, error) {
+	m := &Manifest{Path: path, lines: strings.Split(strings.TrimSuffix(text, "\n"), "\n")}
+	inBlock := false
+	for i, line := range m.lines {
+		fields := strings.Fields(stripComment(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case fields[0] == "package":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s:%d: expected package <name>", path, i+1)
+			}
+			m.Package = fields[1]
+			continue
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown directive %q", path, i+1, fields[0])
+		}
+
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: expected require <name> <git url or path> [version]", path, i+1)
+		}
+		r := &Require{Name: fields[0], Source: fields[1], line: i}
+		if len(fields) == 3 {
+			if r.IsLocal() {
+				return nil, fmt.Errorf("%s:%d: local dependency %s cannot have a version", path, i+1, r.Name)
+			}
+			r.Version = fields[2]
+		}
+		if err := checkRequire(r.Name, r.Source, r.Version); err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", path, i+1, err)
+		}
+		if m.Require(r.Name) != nil {
+			return nil, fmt.Errorf("%s:%d: %s is required twice", path, i+1, r.Name)
+		}
+		m.Requires = append(m.Requires, r)
+	}
+	if inBlock {
+		return nil, fmt.Errorf("%s: unterminated require block", path)
+	}
+	if m.Package == "" {
+		return nil, fmt.Errorf("%s: missing package declaration", path)
+	}
+	return m, nil
+}
+
+// stripComment drops a // comment, one that starts the line or follows a space,
+// so the // of a URL is kept.
+func stripComment(line string) string {
+	for i := 0; i < len(line)-1; i++ {
+		if line[i] == '/' && line[i+1] == '/' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// checkName rejects names that could not be imported, or that would shadow the standard library.
+func checkName(name string) error {
+	if name == "eso" || strings.HasPrefix(name, "eso/") {
+		return fmt.Errorf("dependency name %s is reserved for the standard library", name)
+	}
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "-") || hasDotSegment(name) {
+		return fmt.Errorf("invalid dependency name %s", name)
+	}
+	return nil
+}
+
+// checkRequire rejects a requirement whose name, source or version could leave
+// the cache and vendor directories or be taken by git for an option.
+func checkRequire(name, source, version string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if strings.HasPrefix(source, "-") {
+		return fmt.Errorf("invalid source %s for dependency %s", source, name)
+	}
+	if strings.HasPrefix(version, "-") || hasDotSegment(version) {
+		return fmt.Errorf("invalid version %s for dependency %s", version, name)
+	}
+	return nil
+}
+
+// hasDotSegment reports whether a slash separated name has an empty, "." or ".." segment.
+func hasDotSegment(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, segment := range strings.Split(strings.ReplaceAll(name, "\\", "/"), "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// Require returns the requirement named name, or nil.
+func (m *Manifest) Require(name string) *Require {
+	for _, r := range m.Requires {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// SetRequire adds a requirement, or replaces the one with the same name.
+func (m *Manifest) SetRequire(name, source, version string) error {
+	if err := checkRequire(name, source, version); err != nil {
+		return err
+	}
+	if version != "" && isLocalSource(source) {
+		return fmt.Errorf("local dependency %s cannot have a version", name)
+	}
+	line := strings.TrimSpace(strings.Join([]string{"require", name, source, version}, " "))
+	if r := m.Require(name); r != nil {
+		r.Source, r.Version = source, version
+		if strings.HasPrefix(strings.TrimSpace(m.lines[r.line]), "require") {
+			m.lines[r.line] = line
+		} else {
+			// inside a require ( ... ) block, keep the indentation
+			indent := m.lines[r.line][:len(m.lines[r.line])-len(strings.TrimLeft(m.lines[r.line], " \t"))]
+			m.lines[r.line] = indent + strings.TrimPrefix(line, "require ")
+		}
+		return nil
+	}
+	m.lines = append(m.lines, line)
+	m.Requires = append(m.Requires, &Require{Name: name, Source: source, Version: version, line: len(m.lines) - 1})
+	return nil
+}
+
+// String renders the manifest with the original comments and layout.
+func (m *Manifest) String() string {
+	return strings.Join(m.lines, "\n") + "\n"
+}
+
+// Write saves the manifest to its path.
+func (m *Manifest) Write() error {
+	return os.WriteFile(m.Path, []byte(m.String()), 0644)
+}
+
+// NewManifest returns a manifest for a package without dependencies, to be written at path.
+func NewManifest(path, pkg string) *Manifest {
+	return &Manifest{Path: path, Package: pkg, lines: []string{"package " + pkg, ""}}
+}
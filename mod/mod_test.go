@@ -0,0 +1,235 @@
+package mod
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest("/src/app/esolang.mod", `// the app
+package acme/app
+
+require colors https://github.com/acme/colors.git v1.2.0 // pinned
+require (
+	strutil ../strutil
+	// helpers
+	http/extra git@github.com:acme/extra.git
+)
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Package != "acme/app" || m.Dir() != "/src/app" {
+		t.Errorf("wrong package %q in %q", m.Package, m.Dir())
+	}
+	got := []string{}
+	for _, r := range m.Requires {
+		got = append(got, r.Name+" "+r.Source+" "+r.Version)
+	}
+	expected := []string{"colors https://github.com/acme/colors.git v1.2.0", "strutil ../strutil ", "http/extra git@github.com:acme/extra.git "}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("wrong requires. expected=%q, got=%q", expected, got)
+	}
+	if !m.Require("strutil").IsLocal() || m.Require("colors").IsLocal() {
+		t.Errorf("wrong local detection")
+	}
+
+	if err := m.SetRequire("strutil", "../strutil2", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetRequire("dates", "https://example.com/dates.git", "main"); err != nil {
+		t.Fatal(err)
+	}
+	expectedText := `// the app
+package acme/app
+
+require colors https://github.com/acme/colors.git v1.2.0 // pinned
+require (
+	strutil ../strutil2
+	// helpers
+	http/extra git@github.com:acme/extra.git
+)
+require dates https://example.com/dates.git main
+`
+	if m.String() != expectedText {
+		t.Errorf("wrong manifest after SetRequire. expected=%q, got=%q", expectedText, m.String())
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"require a ../a", "esolang.mod: missing package declaration"},
+		{"package p\nreplace a b", `esolang.mod:2: unknown directive "replace"`},
+		{"package p\nrequire a", "esolang.mod:2: expected require <name> <git url or path> [version]"},
+		{"package p\nrequire a ../a v1", "esolang.mod:2: local dependency a cannot have a version"},
+		{"package p\nrequire eso/math ../m", "esolang.mod:2: dependency name eso/math is reserved for the standard library"},
+		{"package p\nrequire a ../a\nrequire a ../b", "esolang.mod:3: a is required twice"},
+		{"package p\nrequire a/../../x ../a", "esolang.mod:2: invalid dependency name a/../../x"},
+		{"package p\nrequire -a ../a", "esolang.mod:2: invalid dependency name -a"},
+		{"package p\nrequire a --upload-pack=touch", "esolang.mod:2: invalid source --upload-pack=touch for dependency a"},
+		{"package p\nrequire a https://example.com/a.git --orphan", "esolang.mod:2: invalid version --orphan for dependency a"},
+		{"package p\nrequire a https://example.com/a.git v1/../..", "esolang.mod:2: invalid version v1/../.. for dependency a"},
+		{"package p\nrequire (\na ../a", "esolang.mod: unterminated require block"},
+	}
+	for _, tt := range tests {
+		_, err := ParseManifest("esolang.mod", tt.input)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("wrong error for %q. expected=%q, got=%v", tt.input, tt.expected, err)
+		}
+	}
+}
+
+// writeFiles creates files, keyed by their path relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGetLocalDependencies(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ESOLANG_CACHE", filepath.Join(dir, "cache"))
+	writeFiles(t, dir, map[string]string{
+		"app/esolang.mod":          "package app\nrequire strutil ../libs/strutil\n",
+		"libs/strutil/esolang.mod": "package strutil\nrequire colors ../colors\n",
+		"libs/strutil/strutil.eso": "let Name = \"strutil\"",
+		"libs/colors/palette.eso":  "let Red = \"#f00\"",
+	})
+
+	m, err := ReadManifest(filepath.Join(dir, "app", ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock, err := Get(m, GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.Write(filepath.Join(m.Dir(), LockFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	reread, err := ReadLock(filepath.Join(m.Dir(), LockFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reread.String() != lock.String() {
+		t.Errorf("lock did not round trip. expected=%q, got=%q", lock.String(), reread.String())
+	}
+	colors := reread.Entries["colors"]
+	if colors == nil || colors.Source != "../libs/colors" || colors.Commit != "local" || !strings.HasPrefix(colors.Sum, "sha256:") {
+		t.Fatalf("wrong lock entry for the indirect dependency: %+v", colors)
+	}
+
+	dirs, missing, err := Resolve(m.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 || dirs["app"] != m.Dir() || dirs["colors"] != filepath.Join(dir, "libs", "colors") {
+		t.Errorf("wrong resolution %v, missing %v", dirs, missing)
+	}
+
+	if err := Vendor(m, lock); err != nil {
+		t.Fatal(err)
+	}
+	dirs, _, _ = Resolve(m.Path)
+	if dirs["strutil"] != filepath.Join(m.Dir(), VendorDir, "strutil") {
+		t.Errorf("vendored copy not preferred: %v", dirs)
+	}
+	if _, err := os.Stat(filepath.Join(m.Dir(), VendorDir, "colors", "palette.eso")); err != nil {
+		t.Errorf("colors not vendored: %s", err)
+	}
+}
+
+func TestGetConflictingRequirements(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ESOLANG_CACHE", filepath.Join(dir, "cache"))
+	writeFiles(t, dir, map[string]string{
+		"app/esolang.mod":    "package app\nrequire a ../a\nrequire colors ../colors\n",
+		"a/esolang.mod":      "package a\nrequire colors ../other-colors\n",
+		"colors/x.eso":       "",
+		"other-colors/x.eso": "",
+	})
+	m, err := ReadManifest(filepath.Join(dir, "app", ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Get(m, GetOptions{})
+	expected := "a requires colors ../other-colors -, but it is already required as ../colors -"
+	if err == nil || err.Error() != expected {
+		t.Errorf("wrong error. expected=%q, got=%v", expected, err)
+	}
+
+	m.SetRequire("missing", "../nowhere", "")
+	if _, err := Get(m, GetOptions{}); err == nil || !strings.Contains(err.Error(), "local dependency ../nowhere is not a directory") {
+		t.Errorf("expected a missing directory error, got %v", err)
+	}
+}
+
+func TestGetGitDependency(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("ESOLANG_CACHE", filepath.Join(dir, "cache"))
+	writeFiles(t, dir, map[string]string{
+		"colors/palette.eso": `let Red = "#f00"`,
+		"app/esolang.mod":    "package app\nrequire colors file://" + filepath.Join(dir, "colors") + " v1\n",
+	})
+	repo := filepath.Join(dir, "colors")
+	commit := func(message string) string {
+		for _, args := range [][]string{{"add", "-A"}, {"-c", "user.name=eso", "-c", "user.email=eso@example.com", "commit", "-q", "-m", message}} {
+			if _, err := git(repo, args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+		head, _ := git(repo, "rev-parse", "HEAD")
+		return head
+	}
+	if _, err := git(repo, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	first := commit("first")
+	git(repo, "tag", "v1")
+
+	m, err := ReadManifest(filepath.Join(dir, "app", ManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock, err := Get(m, GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock.Write(filepath.Join(m.Dir(), LockFile))
+	if lock.Entries["colors"].Commit != first {
+		t.Fatalf("expected commit %s got %+v", first, lock.Entries["colors"])
+	}
+
+	// moving the tag does not change a locked dependency until get -u
+	os.WriteFile(filepath.Join(repo, "palette.eso"), []byte(`let Red = "red"`), 0644)
+	second := commit("second")
+	git(repo, "tag", "-f", "v1")
+	if lock, _ = Get(m, GetOptions{}); lock.Entries["colors"].Commit != first {
+		t.Errorf("locked commit was not kept: %+v", lock.Entries["colors"])
+	}
+	if lock, _ = Get(m, GetOptions{Update: true}); lock.Entries["colors"].Commit != second {
+		t.Errorf("update did not move to %s: %+v", second, lock.Entries["colors"])
+	}
+	dirs, missing, _ := Resolve(m.Path)
+	b, err := os.ReadFile(filepath.Join(dirs["colors"], "palette.eso"))
+	if err != nil || string(b) != `let Red = "#f00"` || len(missing) != 0 {
+		t.Errorf("expected the locked copy from the cache, got %q %v %v", b, err, missing)
+	}
+}
@@ -0,0 +1,60 @@
+package mod
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Resolve maps each importable package name of the project owning manifest,
+// the project itself included, to its directory. A vendored copy wins over
+// the cache. Dependencies that are not available yet are reported in missing
+// rather than failing, so scripts that do not import them still run.
+func Resolve(manifest string) (dirs map[string]string, missing []string, err error) {
+	m, err := ReadManifest(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	lock, err := ReadLock(filepath.Join(m.Dir(), LockFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	cache, err := CacheDir()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dirs = map[string]string{m.Package: m.Dir()}
+	for name, entry := range lock.Entries {
+		if vendored := filepath.Join(m.Dir(), VendorDir, name); fileExists(vendored) {
+			dirs[name] = vendored
+			continue
+		}
+		dir := entryDir(m.Dir(), cache, entry)
+		if !fileExists(dir) {
+			missing = append(missing, fmt.Sprintf("%s is not downloaded, run `esolang get`", name))
+			continue
+		}
+		dirs[name] = dir
+	}
+
+	// local requirements work before the first get, there is nothing to fetch
+	for _, r := range m.Requires {
+		if _, ok := dirs[r.Name]; ok {
+			continue
+		}
+		if vendored := filepath.Join(m.Dir(), VendorDir, r.Name); fileExists(vendored) {
+			dirs[r.Name] = vendored
+			continue
+		}
+		if r.IsLocal() {
+			if dir, err := localDir(m.Dir(), r.Source); err == nil {
+				dirs[r.Name] = dir
+				continue
+			}
+		}
+		if _, locked := lock.Entries[r.Name]; !locked {
+			missing = append(missing, fmt.Sprintf("%s is not in %s, run `esolang get`", r.Name, LockFile))
+		}
+	}
+	return dirs, missing, nil
+}
@@ -0,0 +1,106 @@
+package object
+
+import (
+	"hash/fnv"
+	"math/big"
+)
+
+// BigInt wraps an arbitrary-precision integer.
+// Integer arithmetic that overflows an int64 is promoted to a BigInt,
+// and a BigInt that fits an int64 again is demoted back to an Integer.
+type BigInt struct {
+	Value *big.Int
+}
+
+// NewBigInt returns an Integer when n fits in an int64, otherwise a BigInt.
+func NewBigInt(n *big.Int) Object {
+	if n.IsInt64() {
+		return &Integer{Value: n.Int64()}
+	}
+	return &BigInt{Value: n}
+}
+
+// ToBigInt converts an Integer or BigInt to a *big.Int.
+func ToBigInt(obj Object) (*big.Int, bool) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return big.NewInt(obj.Value), true
+	case *BigInt:
+		return obj.Value, true
+	default:
+		return nil, false
+	}
+}
+
+func (b *BigInt) Type() ObjectType { return BIGINT_OBJ }
+func (b *BigInt) Inspect() string  { return b.Value.String() }
+func (b *BigInt) HashKey() HashKey {
+	// a value that fits is the same key as the Integer it equals
+	if b.Value.IsInt64() {
+		return (&Integer{Value: b.Value.Int64()}).HashKey()
+	}
+	h := fnv.New64a()
+	h.Write([]byte(b.Value.String()))
+	return HashKey{Type: b.Type(), Value: h.Sum64()}
+}
+func (b *BigInt) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return bigIntInvokables(method, b, args...)
+}
+
+func bigIntInvokables(method string, b *BigInt, args ...Object) Object {
+	name := "BigInt." + method
+	switch method {
+	case "to_string":
+		/*
+			to_string()
+			to_string(base)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		base := int64(10)
+		if len(args) == 1 {
+			base = args[0].(*Integer).Value
+		}
+		if base < 2 || base > 62 {
+			return newError("ValueError: %s() base must be between 2 and 62, got %d", name, base)
+		}
+		return &String{Value: b.Value.Text(int(base))}
+
+	case "abs":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return NewBigInt(new(big.Int).Abs(b.Value))
+
+	case "to_float":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		f, _ := new(big.Float).SetInt(b.Value).Float64()
+		return &Float{Value: f}
+
+	case "to_decimal":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Decimal{Value: new(big.Int).Set(b.Value)}
+
+	case "digits":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: int64(len(new(big.Int).Abs(b.Value).String()))}
+
+	case "is_nan":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: false}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package object
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Channel passes values between tasks. Receiving from a closed, drained channel gives null.
+type Channel struct {
+	ch     chan Object
+	closed atomic.Bool
+}
+
+func NewChannel(size int) *Channel {
+	return &Channel{ch: make(chan Object, size)}
+}
+
+func (c *Channel) Type() ObjectType { return CHANNEL_OBJ }
+func (c *Channel) Inspect() string {
+	return fmt.Sprintf("<channel %d/%d>", len(c.ch), cap(c.ch))
+}
+func (c *Channel) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return channelInvokables(method, c, env.Runtime(), args...)
+}
+
+// Send blocks until the value is taken or buffered, or the evaluation of rt
+// stops and its error is returned.
+func (c *Channel) Send(rt *Runtime, value Object) (result Object) {
+	defer func() {
+		if recover() != nil {
+			result = newError("ChannelError: send on closed channel")
+		}
+	}()
+	select {
+	case c.ch <- value:
+		return value
+	case <-rt.Done():
+		return ContextError(rt.Context().Err())
+	}
+}
+
+// Recv blocks until a value arrives, ok is false once the channel is closed and
+// drained. err is set when the evaluation of rt stops first.
+func (c *Channel) Recv(rt *Runtime) (value Object, ok bool, err *Error) {
+	select {
+	case value, ok = <-c.ch:
+	case <-rt.Done():
+		return nil, false, ContextError(rt.Context().Err())
+	}
+	if !ok {
+		return &Null{}, false, nil
+	}
+	return value, true, nil
+}
+
+func (c *Channel) Close() Object {
+	if !c.closed.CompareAndSwap(false, true) {
+		return newError("ChannelError: channel is already closed")
+	}
+	close(c.ch)
+	return &Null{}
+}
+
+// Select waits on several channels at once. Each case is a channel to receive
+// from or an array [channel, value] to send on. It returns a hash with the index
+// of the case that ran, the value and ok, false when a receive found the channel
+// closed. With a timeout, index is -1 when nothing was ready in time; a zero
+// timeout does not wait at all. The evaluation of rt stopping ends the wait
+// with its error.
+func Select(rt *Runtime, cases []Object, timeout *time.Duration) Object {
+	selectCases := make([]reflect.SelectCase, 0, len(cases)+1)
+	for i, c := range cases {
+		switch c := c.(type) {
+		case *Channel:
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.ch)})
+		case *Array:
+			if len(c.Elements) != 2 {
+				return newError("TypeError: select() case #%d must be [channel, value]", i+1)
+			}
+			ch, ok := c.Elements[0].(*Channel)
+			if !ok {
+				return newError("TypeError: select() case #%d must be [channel, value] got [%s, ...]", i+1, c.Elements[0].Type())
+			}
+			if ch.closed.Load() {
+				return newError("ChannelError: send on closed channel")
+			}
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch.ch), Send: reflect.ValueOf(&c.Elements[1]).Elem()})
+		default:
+			return newError("TypeError: select() case #%d must be a CHANNEL or [channel, value] got `%s`", i+1, c.Type())
+		}
+	}
+	if timeout != nil {
+		if *timeout <= 0 {
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectDefault})
+		} else {
+			timer := time.NewTimer(*timeout)
+			defer timer.Stop()
+			selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+		}
+	}
+	stopped := len(selectCases)
+	selectCases = append(selectCases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(rt.Done())})
+
+	chosen, value, ok, err := trySelect(selectCases)
+	if err != nil {
+		return err
+	}
+	if chosen == stopped {
+		return ContextError(rt.Context().Err())
+	}
+	result := map[string]Object{"index": &Integer{Value: int64(chosen)}, "value": &Null{}, "ok": &Boolean{Value: ok}}
+	switch {
+	case chosen >= len(cases):
+		result["index"] = &Integer{Value: -1}
+		result["ok"] = &Boolean{Value: false}
+	case selectCases[chosen].Dir == reflect.SelectSend:
+		result["value"] = cases[chosen].(*Array).Elements[1]
+		result["ok"] = &Boolean{Value: true}
+	case ok:
+		result["value"] = value.Interface().(Object)
+	}
+
+	pairs := make(map[HashKey]HashPair, len(result))
+	for k, v := range result {
+		key := &String{Value: k}
+		pairs[key.HashKey()] = HashPair{Key: key, Value: v}
+	}
+	return &Hash{Pairs: pairs}
+}
+
+// trySelect turns a send racing with close into an error instead of a crash.
+func trySelect(cases []reflect.SelectCase) (chosen int, value reflect.Value, ok bool, err Object) {
+	defer func() {
+		if recover() != nil {
+			err = newError("ChannelError: send on closed channel")
+		}
+	}()
+	chosen, value, ok = reflect.Select(cases)
+	return chosen, value, ok, nil
+}
+
+func channelInvokables(method string, c *Channel, rt *Runtime, args ...Object) Object {
+	name := "Channel." + method
+	switch method {
+	case "send":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if errObj, ok := c.Send(rt, args[0]).(*Error); ok {
+			return errObj
+		}
+		return &Null{}
+
+	case "recv":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		value, _, err := c.Recv(rt)
+		if err != nil {
+			return err
+		}
+		return value
+
+	case "each":
+		// each(callback) receives until the channel is closed, returning false stops early
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if args[0].Type() != FUNCTION_OBJ && args[0].Type() != BUILTIN_OBJ {
+			return newError("TypeError: %s() callback must be a FUNCTION, got %s", name, args[0].Type())
+		}
+		count := int64(0)
+		for {
+			value, ok, err := c.Recv(rt)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			count++
+			result := CallFunction(args[0], value)
+			if _, isErr := result.(*Error); isErr {
+				return result
+			}
+			if b, isBool := result.(*Boolean); isBool && !b.Value {
+				break
+			}
+		}
+		return &Integer{Value: count}
+
+	case "close":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return c.Close()
+
+	case "closed":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: c.closed.Load()}
+
+	case "len":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: int64(len(c.ch))}
+
+	case "cap":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: int64(cap(c.ch))}
+	}
+	return nil
+}
@@ -0,0 +1,409 @@
+package object
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cliOption is a --name option, or a boolean flag when Default is a Boolean.
+type cliOption struct {
+	Name    string
+	Short   string
+	Help    string
+	Default Object
+}
+
+func (o *cliOption) isFlag() bool {
+	_, ok := o.Default.(*Boolean)
+	return ok
+}
+
+type cliArgument struct {
+	Name string
+	Help string
+}
+
+// Cli describes the options, positional arguments and subcommands of a command line program.
+type Cli struct {
+	Name        string
+	Description string
+	Parent      *Cli
+	Options     []*cliOption
+	Arguments   []cliArgument
+	Commands    []*Cli
+}
+
+func NewCli(name, description string) *Cli {
+	return &Cli{Name: name, Description: description}
+}
+
+func (c *Cli) Type() ObjectType { return CLI_OBJ }
+func (c *Cli) Inspect() string  { return fmt.Sprintf("<cli '%s'>", c.path()) }
+func (c *Cli) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return cliInvokables(method, c, args...)
+}
+
+// path is the program name followed by the names of the subcommands leading to c.
+func (c *Cli) path() string {
+	if c.Parent == nil {
+		return c.Name
+	}
+	return c.Parent.path() + " " + c.Name
+}
+
+func (c *Cli) lookup(name string, short bool) *cliOption {
+	for cli := c; cli != nil; cli = cli.Parent {
+		for _, option := range cli.Options {
+			if !short && option.Name == name || short && option.Short != "" && option.Short == name {
+				return option
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cli) command(name string) *Cli {
+	for _, command := range c.Commands {
+		if command.Name == name {
+			return command
+		}
+	}
+	return nil
+}
+
+func cliInvokables(method string, c *Cli, args ...Object) Object {
+	name := "Cli." + method
+	switch method {
+	case "option":
+		/*
+			option(spec, help)          an option without a default, null when not given
+			option(spec, help, default) the default also sets the type, Integer and Float values are converted
+			spec is the long name optionally followed by a short one, e.g "env|e"
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(2, 3),
+			WithTypes(STRING_OBJ, STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		var value Object = &Null{}
+		if len(args) == 3 {
+			switch args[2].(type) {
+			case *String, *Integer, *Float, *Null:
+				value = args[2]
+			default:
+				return newError("TypeError: %s() default must be a STRING, INTEGER or FLOAT, got %s", name, args[2].Type())
+			}
+		}
+		return c.addOption(name, args[0].(*String).Value, args[1].(*String).Value, value)
+
+	case "flag":
+		// flag(spec, help) a boolean switch that is false unless given
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ, STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return c.addOption(name, args[0].(*String).Value, args[1].(*String).Value, &Boolean{Value: false})
+
+	case "arg":
+		// arg(name, help) a required positional argument
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ, STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		c.Arguments = append(c.Arguments, cliArgument{Name: args[0].(*String).Value, Help: args[1].(*String).Value})
+		return c
+
+	case "command":
+		// command(name, help) declares a subcommand and returns it so it can get its own options
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ, STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		commandName := args[0].(*String).Value
+		if commandName == "" || strings.HasPrefix(commandName, "-") {
+			return newError("ValueError: %s() invalid command name '%s'", name, commandName)
+		}
+		if c.command(commandName) != nil {
+			return newError("ValueError: %s() command '%s' is already declared", name, commandName)
+		}
+		command := NewCli(commandName, args[1].(*String).Value)
+		command.Parent = c
+		c.Commands = append(c.Commands, command)
+		return command
+
+	case "parse":
+		/*
+			parse(args) parses an array of strings such as ARGS and returns a hash with
+			"command" (the chosen subcommand or null), "flags" (every option by long name),
+			"args" (the positional arguments) and "help" (the usage when -h or --help was
+			given, null otherwise). Parsing never exits, the script decides what to do.
+		*/
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(ARRAY_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		elements := args[0].(*Array).Elements
+		values := make([]string, len(elements))
+		for i, element := range elements {
+			s, ok := element.(*String)
+			if !ok {
+				return newError("TypeError: %s() expects an array of strings, got %s", name, element.Type())
+			}
+			values[i] = s.Value
+		}
+		return c.parse(values)
+
+	case "usage":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: c.usage()}
+
+	case "name":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: c.path()}
+	}
+	return nil
+}
+
+func (c *Cli) addOption(method, spec, help string, value Object) Object {
+	long, short, _ := strings.Cut(spec, "|")
+	long = strings.TrimLeft(long, "-")
+	short = strings.TrimLeft(short, "-")
+	if long == "" || long == "help" || short == "h" {
+		return newError("ValueError: %s() invalid option name '%s'", method, spec)
+	}
+	if len([]rune(short)) > 1 {
+		return newError("ValueError: %s() short name '%s' must be a single character", method, short)
+	}
+	if c.lookup(long, false) != nil || short != "" && c.lookup(short, true) != nil {
+		return newError("ValueError: %s() option '%s' is already declared", method, spec)
+	}
+	c.Options = append(c.Options, &cliOption{Name: long, Short: short, Help: help, Default: value})
+	return c
+}
+
+func (c *Cli) usageError(format string, a ...interface{}) Object {
+	return newError("UsageError: %s, see '%s --help'", fmt.Sprintf(format, a...), c.path())
+}
+
+func (c *Cli) parse(args []string) Object {
+	current := c
+	flags := make(map[string]Object)
+	setDefaults := func(cli *Cli) {
+		for _, option := range cli.Options {
+			flags[option.Name] = option.Default
+		}
+	}
+	setDefaults(c)
+
+	var positional []string
+	var help Object = &Null{}
+	onlyPositional := false
+	for i := 0; i < len(args) && help.Type() == NULL_OBJ; i++ {
+		arg := args[i]
+		if arg == "--" && !onlyPositional {
+			onlyPositional = true
+			continue
+		}
+		isOption := !onlyPositional && len(arg) > 1 && arg[0] == '-' && !isNumeric(arg)
+		if !isOption {
+			if len(positional) == 0 && len(current.Commands) > 0 && !onlyPositional {
+				command := current.command(arg)
+				if command == nil {
+					return current.usageError("unknown command '%s'", arg)
+				}
+				current = command
+				setDefaults(current)
+				continue
+			}
+			positional = append(positional, arg)
+			continue
+		}
+
+		if arg == "-h" || arg == "--help" {
+			// the script decides what to do with it, parsing never exits the process
+			help = &String{Value: current.usage()}
+			continue
+		}
+
+		var option *cliOption
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "--") {
+			option = current.lookup(name, false)
+		} else {
+			option = current.lookup(name, true)
+		}
+		if option == nil {
+			return current.usageError("unknown option '%s'", strings.SplitN(arg, "=", 2)[0])
+		}
+
+		if option.isFlag() {
+			if !hasValue {
+				flags[option.Name] = &Boolean{Value: true}
+				continue
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return current.usageError("option '--%s' expects true or false, got '%s'", option.Name, value)
+			}
+			flags[option.Name] = &Boolean{Value: b}
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return current.usageError("option '--%s' requires a value", option.Name)
+			}
+			i++
+			value = args[i]
+		}
+		converted, err := convertCliValue(option.Default, value)
+		if err != nil {
+			return current.usageError("option '--%s' expects %s, got '%s'", option.Name, err, value)
+		}
+		flags[option.Name] = converted
+	}
+
+	if help.Type() == NULL_OBJ && len(positional) < len(current.Arguments) {
+		return current.usageError("missing argument <%s>", current.Arguments[len(positional)].Name)
+	}
+
+	var command Object = &Null{}
+	if current != c {
+		command = &String{Value: strings.TrimPrefix(current.path(), c.path()+" ")}
+	}
+	flagPairs := make(map[HashKey]HashPair)
+	for key, value := range flags {
+		k := &String{Value: key}
+		flagPairs[k.HashKey()] = HashPair{Key: k, Value: value}
+	}
+	positionalObjects := make([]Object, len(positional))
+	for i, value := range positional {
+		positionalObjects[i] = &String{Value: value}
+	}
+
+	result := map[string]Object{
+		"command": command,
+		"flags":   &Hash{Pairs: flagPairs},
+		"args":    &Array{Elements: positionalObjects},
+		"help":    help,
+	}
+	pairs := make(map[HashKey]HashPair)
+	for key, value := range result {
+		k := &String{Value: key}
+		pairs[k.HashKey()] = HashPair{Key: k, Value: value}
+	}
+	return &Hash{Pairs: pairs}
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// convertCliValue converts a command line value to the type of the option's default.
+func convertCliValue(defaultValue Object, value string) (Object, error) {
+	switch defaultValue.(type) {
+	case *Integer:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("an integer")
+		}
+		return &Integer{Value: n}, nil
+	case *Float:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("a number")
+		}
+		return &Float{Value: f}, nil
+	}
+	return &String{Value: value}, nil
+}
+
+// usage renders the help text shown by --help.
+func (c *Cli) usage() string {
+	var out strings.Builder
+
+	line := "Usage: " + c.path() + " [options]"
+	if len(c.Commands) > 0 {
+		line += " <command>"
+	}
+	for _, argument := range c.Arguments {
+		line += " <" + argument.Name + ">"
+	}
+	out.WriteString(line + "\n")
+	if c.Description != "" {
+		out.WriteString("\n" + c.Description + "\n")
+	}
+
+	var rows [][2]string
+	for _, command := range c.Commands {
+		rows = append(rows, [2]string{command.Name, command.Description})
+	}
+	writeCliSection(&out, "Commands", rows)
+
+	rows = nil
+	for _, argument := range c.Arguments {
+		rows = append(rows, [2]string{argument.Name, argument.Help})
+	}
+	writeCliSection(&out, "Arguments", rows)
+
+	writeCliSection(&out, "Options", append(optionRows(c.Options), [2]string{"-h, --help", "Show this help"}))
+	for parent := c.Parent; parent != nil; parent = parent.Parent {
+		writeCliSection(&out, "Global options", optionRows(parent.Options))
+	}
+	return out.String()
+}
+
+func optionRows(options []*cliOption) [][2]string {
+	var rows [][2]string
+	for _, option := range options {
+		left := "    --" + option.Name
+		if option.Short != "" {
+			left = "-" + option.Short + ", --" + option.Name
+		}
+		help := option.Help
+		if !option.isFlag() {
+			left += " <value>"
+			if _, isNull := option.Default.(*Null); !isNull {
+				help += " (default: " + option.Default.Inspect() + ")"
+			}
+		}
+		rows = append(rows, [2]string{left, help})
+	}
+	return rows
+}
+
+func writeCliSection(out *strings.Builder, title string, rows [][2]string) {
+	if len(rows) == 0 {
+		return
+	}
+	width := 0
+	for _, row := range rows {
+		if len(row[0]) > width {
+			width = len(row[0])
+		}
+	}
+	out.WriteString("\n" + title + ":\n")
+	for _, row := range rows {
+		fmt.Fprintf(out, "  %-*s  %s\n", width, row[0], row[1])
+	}
+}
@@ -0,0 +1,163 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// ToGo converts an object to a Go value:
+//
+//	NULL      nil
+//	BOOLEAN   bool
+//	INTEGER   int64
+//	FLOAT     float64
+//	STRING    string
+//	BIGINT    *big.Int
+//	DATETIME  time.Time
+//	DURATION  time.Duration
+//	ARRAY     []any
+//	SET       []any
+//	HASH      map[string]any, keys that are not strings are printed
+//
+// Any other object, e.g. a function or a decimal, is returned as is.
+func ToGo(obj Object) any {
+	switch o := obj.(type) {
+	case *Null:
+		return nil
+	case *Boolean:
+		return o.Value
+	case *Integer:
+		return o.Value
+	case *Float:
+		return o.Value
+	case *String:
+		return o.Value
+	case *BigInt:
+		return new(big.Int).Set(o.Value)
+	case *DateTime:
+		return o.Value
+	case *Duration:
+		return o.Value
+	case *Array:
+		return toGoSlice(o.Elements)
+	case *Set:
+		return toGoSlice(o.Elements)
+	case *Hash:
+		m := make(map[string]any, len(o.Pairs))
+		for _, pair := range o.Pairs {
+			key := pair.Key.Inspect()
+			if s, ok := pair.Key.(*String); ok {
+				key = s.Value
+			}
+			m[key] = ToGo(pair.Value)
+		}
+		return m
+	}
+	return obj
+}
+
+func toGoSlice(elements []Object) []any {
+	values := make([]any, len(elements))
+	for i, el := range elements {
+		values[i] = ToGo(el)
+	}
+	return values
+}
+
+// FromGo converts a Go value to an object. It takes the types ToGo returns,
+// any integer, float, slice, array or map of those, pointers to them and
+// objects as they are. A func(...Object) Object becomes a builtin as is,
+// other functions are wrapped by NewGoFunction. Integers beyond int64 become
+// BIGINT, map keys must be strings, numbers or booleans.
+func FromGo(value any) (Object, error) {
+	switch v := value.(type) {
+	case nil:
+		return &Null{}, nil
+	case Object:
+		return v, nil
+	case BuiltinFunction:
+		return &Builtin{Fn: v}, nil
+	case func(...Object) Object:
+		return &Builtin{Fn: v}, nil
+	case time.Time:
+		return &DateTime{Value: v}, nil
+	case time.Duration:
+		return &Duration{Value: v}, nil
+	case *big.Int:
+		if v == nil {
+			return &Null{}, nil
+		}
+		return NewBigInt(new(big.Int).Set(v)), nil
+	case []byte:
+		return &String{Value: string(v)}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return &Boolean{Value: rv.Bool()}, nil
+	case reflect.String:
+		return &String{Value: rv.String()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Integer{Value: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := rv.Uint(); n > math.MaxInt64 {
+			return &BigInt{Value: new(big.Int).SetUint64(n)}, nil
+		}
+		return &Integer{Value: int64(rv.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Float{Value: rv.Float()}, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return &Null{}, nil
+		}
+		return FromGo(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return &Null{}, nil
+		}
+		elements := make([]Object, rv.Len())
+		for i := range elements {
+			el, err := FromGo(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			elements[i] = el
+		}
+		return &Array{Elements: elements}, nil
+	case reflect.Func:
+		if rv.IsNil() {
+			return &Null{}, nil
+		}
+		return NewGoFunction(goFuncName(rv), value)
+	case reflect.Map:
+		if rv.IsNil() {
+			return &Null{}, nil
+		}
+		pairs := make(map[HashKey]HashPair, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := FromGo(iter.Key().Interface())
+			if err != nil {
+				return nil, err
+			}
+			hashable, ok := key.(Hashable)
+			if !ok {
+				return nil, fmt.Errorf("%w %T as a hash key", errUnsupportedType, iter.Key().Interface())
+			}
+			val, err := FromGo(iter.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("[%v]: %w", iter.Key().Interface(), err)
+			}
+			pairs[hashable.HashKey()] = HashPair{Key: key, Value: val}
+		}
+		return &Hash{Pairs: pairs}, nil
+	}
+	return nil, fmt.Errorf("%w %T", errUnsupportedType, value)
+}
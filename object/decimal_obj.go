@@ -0,0 +1,318 @@
+package object
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalDivisionScale is the number of digits after the decimal point kept when
+// a division does not terminate, e.g. Decimal("1") / Decimal("3").
+const DecimalDivisionScale = 28
+
+// MaxDecimalScale bounds the exponent of a parsed decimal and the number of
+// digits after its decimal point, so "1e90000000" cannot allocate a huge number.
+const MaxDecimalScale = 10000
+
+// Decimal is an exact base-10 number: Value * 10^-Scale.
+// Unlike Float, Decimal("0.1") + Decimal("0.2") is exactly Decimal("0.3").
+type Decimal struct {
+	Value *big.Int // the unscaled digits
+	Scale int32    // the number of digits after the decimal point
+}
+
+var bigTen = big.NewInt(10)
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// ParseDecimal parses strings such as "19.99", "-0.5" or "1.5e3".
+func ParseDecimal(s string) (*Decimal, error) {
+	text := strings.TrimSpace(s)
+	exponent := int64(0)
+	if idx := strings.IndexAny(text, "eE"); idx >= 0 {
+		e, err := strconv.ParseInt(text[idx+1:], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal %q", s)
+		}
+		if e > MaxDecimalScale || e < -MaxDecimalScale {
+			return nil, fmt.Errorf("decimal exponent of %q out of range [-%d, %d]", s, MaxDecimalScale, MaxDecimalScale)
+		}
+		exponent = e
+		text = text[:idx]
+	}
+
+	scale := int64(0)
+	if idx := strings.Index(text, "."); idx >= 0 {
+		scale = int64(len(text) - idx - 1)
+		text = text[:idx] + text[idx+1:]
+	}
+
+	digits := strings.TrimLeft(text, "+-")
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 || len(text)-len(digits) > 1 {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	value, _ := new(big.Int).SetString(text, 10)
+	scale -= exponent
+	if scale > MaxDecimalScale {
+		return nil, fmt.Errorf("decimal %q has more than %d digits after the decimal point", s, MaxDecimalScale)
+	}
+	if scale < 0 {
+		value.Mul(value, pow10(int32(-scale)))
+		scale = 0
+	}
+	return &Decimal{Value: value, Scale: int32(scale)}, nil
+}
+
+// ToDecimal converts Integer, BigInt, Float and Decimal objects to a Decimal.
+// Floats are converted through their shortest decimal representation.
+func ToDecimal(obj Object) (*Decimal, bool) {
+	switch obj := obj.(type) {
+	case *Decimal:
+		return obj, true
+	case *Integer:
+		return &Decimal{Value: big.NewInt(obj.Value)}, true
+	case *BigInt:
+		return &Decimal{Value: new(big.Int).Set(obj.Value)}, true
+	case *Float:
+		d, err := ParseDecimal(strconv.FormatFloat(obj.Value, 'f', -1, 64))
+		return d, err == nil
+	default:
+		return nil, false
+	}
+}
+
+func (d *Decimal) Type() ObjectType { return DECIMAL_OBJ }
+func (d *Decimal) Inspect() string {
+	digits := new(big.Int).Abs(d.Value).String()
+	sign := ""
+	if d.Value.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale == 0 {
+		return sign + digits
+	}
+	scale := int(d.Scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// HashKey returns the same key for equal decimals regardless of trailing zeros.
+func (d *Decimal) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(d.normalize().Inspect()))
+	return HashKey{Type: d.Type(), Value: h.Sum64()}
+}
+
+func (d *Decimal) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return decimalInvokables(method, d, args...)
+}
+
+// rescale returns d with exactly scale digits after the decimal point,
+// rounding half to even when digits are dropped.
+func (d *Decimal) rescale(scale int32) *Decimal {
+	if scale == d.Scale {
+		return d
+	}
+	if scale > d.Scale {
+		return &Decimal{Value: new(big.Int).Mul(d.Value, pow10(scale-d.Scale)), Scale: scale}
+	}
+	divisor := pow10(d.Scale - scale)
+	quotient, remainder := new(big.Int).QuoRem(d.Value, divisor, new(big.Int))
+	half := new(big.Int).Abs(remainder)
+	half.Mul(half, big.NewInt(2))
+	if cmp := half.Cmp(divisor); cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		if d.Value.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return &Decimal{Value: quotient, Scale: scale}
+}
+
+// normalize strips trailing zeros after the decimal point.
+func (d *Decimal) normalize() *Decimal {
+	value, scale := new(big.Int).Set(d.Value), d.Scale
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, r := new(big.Int).QuoRem(value, bigTen, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+		value, scale = quotient, scale-1
+	}
+	return &Decimal{Value: value, Scale: scale}
+}
+
+func align(a, b *Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.Scale, b.Scale)
+	return a.rescale(scale).Value, b.rescale(scale).Value, scale
+}
+
+func (d *Decimal) Add(other *Decimal) *Decimal {
+	a, b, scale := align(d, other)
+	return &Decimal{Value: new(big.Int).Add(a, b), Scale: scale}
+}
+
+func (d *Decimal) Sub(other *Decimal) *Decimal {
+	a, b, scale := align(d, other)
+	return &Decimal{Value: new(big.Int).Sub(a, b), Scale: scale}
+}
+
+func (d *Decimal) Mul(other *Decimal) *Decimal {
+	return &Decimal{Value: new(big.Int).Mul(d.Value, other.Value), Scale: d.Scale + other.Scale}
+}
+
+// Quo divides d by other, keeping up to DecimalDivisionScale digits after the
+// decimal point but no more trailing zeros than either operand had.
+// other must not be zero.
+func (d *Decimal) Quo(other *Decimal) *Decimal {
+	scale := int32(DecimalDivisionScale) + 1
+	// (d.Value * 10^(scale + other.Scale - d.Scale)) / other.Value carries `scale` digits
+	numerator := new(big.Int).Set(d.Value)
+	shift := scale + other.Scale - d.Scale
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		numerator.Quo(numerator, pow10(-shift))
+	}
+	value, remainder := new(big.Int).QuoRem(numerator, other.Value, new(big.Int))
+	// an inexact quotient ending in 5 is above the halfway point, not on it
+	if remainder.Sign() != 0 && new(big.Int).Rem(new(big.Int).Abs(value), bigTen).Int64() == 5 {
+		if value.Sign() < 0 {
+			value.Sub(value, big.NewInt(1))
+		} else {
+			value.Add(value, big.NewInt(1))
+		}
+	}
+	quotient := (&Decimal{Value: value, Scale: scale}).rescale(DecimalDivisionScale).normalize()
+	if minScale := max(d.Scale, other.Scale); quotient.Scale < minScale {
+		quotient = quotient.rescale(minScale)
+	}
+	return quotient
+}
+
+// Rem returns the remainder of truncated division, with the sign of d.
+// other must not be zero.
+func (d *Decimal) Rem(other *Decimal) *Decimal {
+	a, b, scale := align(d, other)
+	return &Decimal{Value: new(big.Int).Rem(a, b), Scale: scale}
+}
+
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{Value: new(big.Int).Neg(d.Value), Scale: d.Scale}
+}
+
+func (d *Decimal) Cmp(other *Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+func (d *Decimal) IsZero() bool { return d.Value.Sign() == 0 }
+
+// checkDecimalPlaces returns a ValueError unless places fits in a scale.
+func checkDecimalPlaces(name string, places int64) Object {
+	if places < 0 {
+		return newError("ValueError: %s() places must not be negative, got %d", name, places)
+	}
+	if places > MaxDecimalScale {
+		return newError("ValueError: %s() places must be at most %d, got %d", name, MaxDecimalScale, places)
+	}
+	return nil
+}
+
+func decimalInvokables(method string, d *Decimal, args ...Object) Object {
+	name := "Decimal." + method
+	switch method {
+	case "round":
+		/*
+			round()       rounds to a whole number
+			round(places) rounds to the given number of decimal places
+			halves are rounded to even, i.e. banker's rounding
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		places := int64(0)
+		if len(args) == 1 {
+			places = args[0].(*Integer).Value
+		}
+		if err := checkDecimalPlaces(name, places); err != nil {
+			return err
+		}
+		return d.rescale(int32(places))
+
+	case "to_string":
+		/*
+			to_string()
+			to_string(places)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 1 {
+			places := args[0].(*Integer).Value
+			if err := checkDecimalPlaces(name, places); err != nil {
+				return err
+			}
+			return &String{Value: d.rescale(int32(places)).Inspect()}
+		}
+		return &String{Value: d.Inspect()}
+
+	case "to_int":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return NewBigInt(new(big.Int).Quo(d.Value, pow10(d.Scale)))
+
+	case "to_float":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		f, err := strconv.ParseFloat(d.Inspect(), 64)
+		if err != nil {
+			return newError("ValueError: %s", err)
+		}
+		return &Float{Value: f}
+
+	case "abs":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Decimal{Value: new(big.Int).Abs(d.Value), Scale: d.Scale}
+
+	case "normalize":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return d.normalize()
+
+	case "scale":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: int64(d.Scale)}
+
+	case "is_nan":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: false}
+	}
+	return nil
+}
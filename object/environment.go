@@ -1,19 +1,29 @@
 package object
 
-import "unicode"
+import (
+	"sync"
+	"unicode"
+)
 
+// Environment holds the variables of a scope. It is safe to share between
+// spawned tasks, each scope is guarded by its own lock.
 type Environment struct {
 	store map[string]Object
 	outer *Environment
+	mu    *sync.RWMutex
+	// runtime is only set on outermost environments, see Runtime.
+	runtime *Runtime
 }
 
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
-	return &Environment{store: s, outer: nil}
+	return &Environment{store: s, outer: nil, mu: &sync.RWMutex{}}
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
+	e.mu.RLock()
 	obj, ok := e.store[name]
+	e.mu.RUnlock()
 	if !ok && e.outer != nil {
 		obj, ok = e.outer.Get(name)
 	}
@@ -21,11 +31,15 @@ func (e *Environment) Get(name string) (Object, bool) {
 }
 
 func (e *Environment) Set(name string, val Object) Object {
+	e.mu.Lock()
 	e.store[name] = val
+	e.mu.Unlock()
 	return val
 }
 
 func (e *Environment) ExportedHash() *Hash {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	pairs := make(map[HashKey]HashPair)
 	for k, v := range e.store {
 		if unicode.IsUpper(rune(k[0])) {
@@ -36,6 +50,23 @@ func (e *Environment) ExportedHash() *Hash {
 	return &Hash{Pairs: pairs}
 }
 
+// SetRuntime makes the scripts evaluated in e, and the scopes it encloses, use rt.
+func (e *Environment) SetRuntime(rt *Runtime) {
+	e.mu.Lock()
+	e.runtime = rt
+	e.mu.Unlock()
+}
+
+// Runtime returns the runtime of the outermost environment, DefaultRuntime when it has none.
+func (e *Environment) Runtime() *Runtime {
+	for e.outer != nil {
+		e = e.outer
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.runtime.or()
+}
+
 func NewEnclosedEnvironment(outer *Environment) *Environment {
 	env := NewEnvironment()
 	env.outer = outer
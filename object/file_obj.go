@@ -0,0 +1,176 @@
+package object
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"syscall"
+)
+
+// File is an open file handle used to stream large files instead of reading them whole.
+type File struct {
+	Name   string
+	Mode   string
+	Handle io.ReadWriteCloser
+	Reader *bufio.Reader
+	Closed bool
+}
+
+func NewFile(name, mode string, handle io.ReadWriteCloser) *File {
+	return &File{Name: name, Mode: mode, Handle: handle, Reader: bufio.NewReader(handle)}
+}
+
+func (f *File) Type() ObjectType { return FILE_OBJ }
+func (f *File) Inspect() string {
+	state := "open"
+	if f.Closed {
+		state = "closed"
+	}
+	return fmt.Sprintf("<file '%s' mode='%s' %s>", f.Name, f.Mode, state)
+}
+func (f *File) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return fileInvokables(method, f, args...)
+}
+
+// unread moves the handle back over the bytes the reader buffered but the
+// script has not read yet, so a write lands right after what was read.
+func (f *File) unread() error {
+	buffered := f.Reader.Buffered()
+	if buffered == 0 {
+		return nil
+	}
+	seeker, ok := f.Handle.(io.Seeker)
+	if !ok {
+		return fmt.Errorf("write to '%s' after reading ahead of it: %w", f.Name, errors.ErrUnsupported)
+	}
+	if _, err := seeker.Seek(-int64(buffered), io.SeekCurrent); err != nil {
+		return err
+	}
+	f.Reader.Reset(f.Handle)
+	return nil
+}
+
+// FileError turns an error from the os package into a typed esolang error,
+// e.g. "FileNotFoundError: open data.txt: no such file or directory".
+func FileError(err error) Object {
+	kind := "IOError"
+	switch {
+	// ENOTEMPTY also matches fs.ErrExist, so it has to be checked first
+	case errors.Is(err, syscall.ENOTEMPTY):
+		kind = "DirectoryNotEmptyError"
+	case errors.Is(err, syscall.EISDIR):
+		kind = "IsADirectoryError"
+	case errors.Is(err, syscall.ENOTDIR):
+		kind = "NotADirectoryError"
+	case errors.Is(err, fs.ErrNotExist):
+		kind = "FileNotFoundError"
+	case errors.Is(err, fs.ErrExist):
+		kind = "FileExistsError"
+	case errors.Is(err, fs.ErrPermission):
+		kind = "PermissionError"
+	case errors.Is(err, fs.ErrClosed):
+		kind = "ClosedFileError"
+	}
+	return newError("%s: %s", kind, err)
+}
+
+func fileInvokables(method string, f *File, args ...Object) Object {
+	name := "File." + method
+	if f.Closed && method != "close" && method != "name" && method != "is_closed" {
+		return newError("ClosedFileError: %s() on closed file '%s'", name, f.Name)
+	}
+
+	switch method {
+	case "read":
+		/*
+			read()  reads the rest of the file
+			read(n) reads at most n bytes, returns null at the end of the file
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 0 {
+			content, err := io.ReadAll(f.Reader)
+			if err != nil {
+				return FileError(err)
+			}
+			return &String{Value: string(content)}
+		}
+		n := args[0].(*Integer).Value
+		if n < 0 {
+			return newError("ValueError: %s() size must not be negative, got %d", name, n)
+		}
+		buffer := make([]byte, n)
+		read, err := io.ReadFull(f.Reader, buffer)
+		if read == 0 && err == io.EOF {
+			return &Null{}
+		}
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return FileError(err)
+		}
+		return &String{Value: string(buffer[:read])}
+
+	case "read_line":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		line, err := f.Reader.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return &Null{}
+		}
+		if err != nil && err != io.EOF {
+			return FileError(err)
+		}
+		return &String{Value: strings.TrimRight(line, "\r\n")}
+
+	case "write":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if err := f.unread(); err != nil {
+			return FileError(err)
+		}
+		written, err := io.WriteString(f.Handle, args[0].(*String).Value)
+		if err != nil {
+			return FileError(err)
+		}
+		return &Integer{Value: int64(written)}
+
+	case "close":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if f.Closed {
+			return &Null{}
+		}
+		f.Closed = true
+		if err := f.Handle.Close(); err != nil {
+			return FileError(err)
+		}
+		return &Null{}
+
+	case "name":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: f.Name}
+
+	case "is_closed":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: f.Closed}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package object
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatFloat renders f using the shortest representation that reads back to the same value.
+// Whole numbers keep a trailing ".0" so they are not mistaken for integers.
+func FormatFloat(f float64) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	var s string
+	if abs := math.Abs(f); abs == 0 || (abs >= 1e-4 && abs < 1e16) {
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	} else {
+		s = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+func floatInvokables(method string, f *Float, args ...Object) Object {
+	name := "Float." + method
+	switch method {
+	case "to_string":
+		/*
+			to_string()
+			to_string(precision)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 1 {
+			return formatFixed(f.Value, args[0].(*Integer).Value)
+		}
+		return &String{Value: FormatFloat(f.Value)}
+
+	case "round":
+		/*
+			round()          rounds half away from zero and returns an Integer
+			round(precision) rounds to precision decimal places and returns a Float
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 0 {
+			return floatToInteger(name, math.Round(f.Value))
+		}
+		precision := args[0].(*Integer).Value
+		if precision < 0 {
+			return newError("ValueError: precision must not be negative, got %d", precision)
+		}
+		rounded, err := strconv.ParseFloat(strconv.FormatFloat(f.Value, 'f', int(precision), 64), 64)
+		if err != nil {
+			return newError("ValueError: %s", err)
+		}
+		return &Float{Value: rounded}
+
+	case "floor":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return floatToInteger(name, math.Floor(f.Value))
+
+	case "ceil":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return floatToInteger(name, math.Ceil(f.Value))
+
+	case "to_int":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return floatToInteger(name, math.Trunc(f.Value))
+
+	case "to_float":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return f
+
+	case "abs":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Float{Value: math.Abs(f.Value)}
+
+	case "is_nan":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: math.IsNaN(f.Value)}
+
+	case "is_inf":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: math.IsInf(f.Value, 0)}
+
+	case "clamp":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return clampFloat(name, f.Value, args)
+	}
+	return nil
+}
+
+// floatToInteger converts an already rounded float to an Integer,
+// refusing values that have no integer representation.
+func floatToInteger(name string, f float64) Object {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return newError("ValueError: %s() cannot convert %s to an integer", name, FormatFloat(f))
+	}
+	return &Integer{Value: int64(f)}
+}
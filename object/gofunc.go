@@ -0,0 +1,269 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var (
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	objectType   = reflect.TypeOf((*Object)(nil)).Elem()
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// NewGoFunction wraps an ordinary Go function as a builtin, e.g.
+//
+//	func(name string, n int) (map[string]any, error)
+//
+// Calls are checked with CheckTypings against the parameters: strings take a
+// STRING, bools a BOOLEAN, integers an INTEGER, floats a FLOAT or an INTEGER,
+// *big.Int an INTEGER or a BIGINT, time.Time a DATETIME, time.Duration a
+// DURATION, slices an ARRAY, maps with string keys a HASH, any parameter of
+// interface type any value converted by ToGo, and an Object parameter the
+// object itself. A variadic function takes any number of trailing arguments.
+//
+// The function may return nothing, a value converted by FromGo, an error or a
+// value and an error. A non nil error becomes an *Error, its message is kept
+// when it starts with an error kind like "ValueError: " and is prefixed with
+// "RuntimeError: " otherwise. name is the function's name in messages.
+func NewGoFunction(name string, fn any) (*Builtin, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, fmt.Errorf("%T is not a function", fn)
+	}
+	ft := fv.Type()
+
+	params := make([][]ObjectType, ft.NumIn())
+	for i := range params {
+		t := ft.In(i)
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			t = t.Elem()
+		}
+		types, err := acceptedTypes(t)
+		if err != nil {
+			return nil, fmt.Errorf("parameter #%d: %w", i+1, err)
+		}
+		params[i] = types
+	}
+	returnsError := ft.NumOut() > 0 && ft.Out(ft.NumOut()-1) == errorType
+	if ft.NumOut() > 2 || (ft.NumOut() == 2 && !returnsError) {
+		return nil, fmt.Errorf("a function returns at most a value and an error, got %s", ft)
+	}
+
+	return &Builtin{Fn: func(args ...Object) Object {
+		fixed := ft.NumIn()
+		arity := ExactArgsLength(fixed)
+		if ft.IsVariadic() {
+			fixed--
+			arity = MinimumArgs(fixed)
+		}
+		types := append([][]ObjectType{}, params[:fixed]...)
+		for ft.IsVariadic() && len(types) < len(args) {
+			types = append(types, params[fixed])
+		}
+		if err := CheckTypings(name, args, arity, WithTypeSets(types...)); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			var t reflect.Type
+			if i < fixed {
+				t = ft.In(i)
+			} else {
+				t = ft.In(fixed).Elem()
+			}
+			v, err := goValue(arg, t)
+			if err != nil {
+				return newError("TypeError: %s() argument #%d %s", name, i+1, err)
+			}
+			in[i] = v
+		}
+
+		out := fv.Call(in)
+		if returnsError {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return goError(err)
+			}
+			out = out[:len(out)-1]
+		}
+		if len(out) == 0 {
+			return &Null{}
+		}
+		result, err := FromGo(out[0].Interface())
+		if err != nil {
+			return newError("TypeError: %s() returned %s", name, err)
+		}
+		return result
+	}}, nil
+}
+
+// acceptedTypes is the object types a parameter of type t takes, nil takes any.
+func acceptedTypes(t reflect.Type) ([]ObjectType, error) {
+	switch t {
+	case bigIntType:
+		return []ObjectType{INTEGER_OBJ, BIGINT_OBJ}, nil
+	case timeType:
+		return []ObjectType{DATETIME_OBJ}, nil
+	case durationType:
+		return []ObjectType{DURATION_OBJ}, nil
+	}
+	if t.Implements(objectType) {
+		if t.Kind() == reflect.Pointer {
+			return []ObjectType{reflect.New(t.Elem()).Interface().(Object).Type()}, nil
+		}
+		return nil, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return []ObjectType{STRING_OBJ}, nil
+	case reflect.Bool:
+		return []ObjectType{BOOLEAN_OBJ}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []ObjectType{INTEGER_OBJ}, nil
+	case reflect.Float32, reflect.Float64:
+		return []ObjectType{FLOAT_OBJ, INTEGER_OBJ}, nil
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return nil, nil
+		}
+	case reflect.Slice:
+		if _, err := acceptedTypes(t.Elem()); err != nil {
+			return nil, err
+		}
+		return []ObjectType{ARRAY_OBJ}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w %s, map keys must be strings", errUnsupportedType, t)
+		}
+		if _, err := acceptedTypes(t.Elem()); err != nil {
+			return nil, err
+		}
+		return []ObjectType{HASH_OBJ}, nil
+	}
+	return nil, fmt.Errorf("%w %s", errUnsupportedType, t)
+}
+
+// goValue converts obj to a value of type t, obj has one of the types acceptedTypes allows.
+func goValue(obj Object, t reflect.Type) (reflect.Value, error) {
+	if types, _ := acceptedTypes(t); types != nil && !hasType(types, obj.Type()) {
+		return reflect.Value{}, fmt.Errorf("expected `%s` got `%s`", joinTypes(types), obj.Type())
+	}
+	v := reflect.New(t).Elem()
+	switch {
+	case t == bigIntType:
+		n, _ := ToBigInt(obj)
+		return reflect.ValueOf(n), nil
+	case t == timeType:
+		return reflect.ValueOf(obj.(*DateTime).Value), nil
+	case t == durationType:
+		return reflect.ValueOf(obj.(*Duration).Value), nil
+	case t.Implements(objectType):
+		return reflect.ValueOf(obj), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(obj.(*String).Value)
+	case reflect.Bool:
+		v.SetBool(obj.(*Boolean).Value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := obj.(*Integer).Value
+		if v.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf("%d does not fit in %s", n, t)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := obj.(*Integer).Value
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return reflect.Value{}, fmt.Errorf("%d does not fit in %s", n, t)
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		if i, ok := obj.(*Integer); ok {
+			v.SetFloat(float64(i.Value))
+		} else {
+			v.SetFloat(obj.(*Float).Value)
+		}
+	case reflect.Interface:
+		if value := ToGo(obj); value != nil {
+			v.Set(reflect.ValueOf(value))
+		}
+	case reflect.Slice:
+		elements := obj.(*Array).Elements
+		v = reflect.MakeSlice(t, len(elements), len(elements))
+		for i, el := range elements {
+			ev, err := goValue(el, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element #%d %s", i+1, err)
+			}
+			v.Index(i).Set(ev)
+		}
+	case reflect.Map:
+		v = reflect.MakeMapWithSize(t, len(obj.(*Hash).Pairs))
+		for _, pair := range obj.(*Hash).Pairs {
+			key, ok := pair.Key.(*String)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("key %s is not a `STRING`", pair.Key.Inspect())
+			}
+			ev, err := goValue(pair.Value, t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("key %q %s", key.Value, err)
+			}
+			v.SetMapIndex(reflect.ValueOf(key.Value).Convert(t.Key()), ev)
+		}
+	}
+	return v, nil
+}
+
+func hasType(types []ObjectType, t ObjectType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
+
+func joinTypes(types []ObjectType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, "` or `")
+}
+
+// errorKind matches a message that already names its kind, e.g. "ValueError: ...".
+var errorKind = regexp.MustCompile(`^[A-Z][A-Za-z]*Error: `)
+
+// goError turns an error returned by a Go function into an *Error.
+func goError(err error) Object {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return FileError(err)
+	}
+	if errorKind.MatchString(err.Error()) {
+		return newError("%s", err)
+	}
+	return newError("RuntimeError: %s", err)
+}
+
+// goFuncName is the name of a Go function without its package, for messages.
+func goFuncName(fn reflect.Value) string {
+	name := "function"
+	if f := runtime.FuncForPC(fn.Pointer()); f != nil {
+		name = f.Name()[strings.LastIndex(f.Name(), ".")+1:]
+	}
+	return name
+}
@@ -1,11 +1,122 @@
 package object
 
-import "strconv"
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
 
-func intInvokables(method string, i *Integer) Object {
-	if method == "to_string" {
-		stringVal := strconv.FormatInt(i.Value, 10)
-		return &String{Value: stringVal}
+func intInvokables(method string, i *Integer, args ...Object) Object {
+	name := "Integer." + method
+	switch method {
+	case "to_string":
+		/*
+			to_string()
+			to_string(precision)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 1 {
+			return formatFixed(float64(i.Value), args[0].(*Integer).Value)
+		}
+		return &String{Value: strconv.FormatInt(i.Value, 10)}
+
+	case "round", "floor", "ceil":
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return i
+
+	case "to_int":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return i
+
+	case "to_float":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Float{Value: float64(i.Value)}
+
+	case "abs":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if i.Value == math.MinInt64 {
+			// its absolute value is one past the largest Integer
+			return NewBigInt(new(big.Int).Abs(big.NewInt(i.Value)))
+		}
+		if i.Value < 0 {
+			return &Integer{Value: -i.Value}
+		}
+		return i
+
+	case "is_nan":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: false}
+
+	case "clamp":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if args[0].Type() == INTEGER_OBJ && args[1].Type() == INTEGER_OBJ {
+			low, high := args[0].(*Integer).Value, args[1].(*Integer).Value
+			if low > high {
+				return newError("ValueError: %s() lower bound %d is greater than upper bound %d", name, low, high)
+			}
+			return &Integer{Value: max(low, min(i.Value, high))}
+		}
+		return clampFloat(name, float64(i.Value), args)
 	}
 	return nil
 }
+
+// ToFloat converts an Integer or Float to a float64, ok is false for other objects.
+func ToFloat(obj Object) (float64, bool) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return float64(obj.Value), true
+	case *Float:
+		return obj.Value, true
+	default:
+		return 0, false
+	}
+}
+
+// formatFixed renders value with exactly precision digits after the decimal point.
+func formatFixed(value float64, precision int64) Object {
+	if precision < 0 {
+		return newError("ValueError: precision must not be negative, got %d", precision)
+	}
+	return &String{Value: strconv.FormatFloat(value, 'f', int(precision), 64)}
+}
+
+func clampFloat(name string, value float64, args []Object) Object {
+	low, ok := ToFloat(args[0])
+	if !ok {
+		return newError("TypeError: %s() expected argument #1 to be a number got `%s`", name, args[0].Type())
+	}
+	high, ok := ToFloat(args[1])
+	if !ok {
+		return newError("TypeError: %s() expected argument #2 to be a number got `%s`", name, args[1].Type())
+	}
+	if low > high {
+		return newError("ValueError: %s() lower bound %s is greater than upper bound %s", name, args[0].Inspect(), args[1].Inspect())
+	}
+	return &Float{Value: math.Max(low, math.Min(value, high))}
+}
@@ -0,0 +1,174 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ParseJSON parses a single JSON document into objects, syntax errors carry their line and column.
+func ParseJSON(text string) (Object, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column := jsonPosition(text, syntaxErr.Offset)
+			return nil, fmt.Errorf("%s at line %d, column %d", syntaxErr, line, column)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			line, column := jsonPosition(text, int64(len(text))+1)
+			return nil, fmt.Errorf("unexpected end of JSON input at line %d, column %d", line, column)
+		}
+		return nil, err
+	}
+	// only whitespace may follow the document
+	if offset := decoder.InputOffset(); strings.TrimSpace(text[offset:]) != "" {
+		start := offset + int64(len(text[offset:])-len(strings.TrimLeft(text[offset:], " \t\r\n")))
+		line, column := jsonPosition(text, start+1)
+		return nil, fmt.Errorf("unexpected data after top-level value at line %d, column %d", line, column)
+	}
+	return jsonToObject(value), nil
+}
+
+// jsonPosition converts a 1-based byte offset into a line and column.
+func jsonPosition(text string, offset int64) (int, int) {
+	if offset > int64(len(text))+1 {
+		offset = int64(len(text)) + 1
+	}
+	line, column := 1, 1
+	for _, c := range text[:max(offset-1, 0)] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func jsonToObject(value interface{}) Object {
+	switch v := value.(type) {
+	case nil:
+		return &Null{}
+	case bool:
+		return &Boolean{Value: v}
+	case string:
+		return &String{Value: v}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return &Integer{Value: n}
+		}
+		if n, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return NewBigInt(n)
+		}
+		f, _ := v.Float64()
+		return &Float{Value: f}
+	case []interface{}:
+		elements := make([]Object, len(v))
+		for i, element := range v {
+			elements[i] = jsonToObject(element)
+		}
+		return &Array{Elements: elements}
+	case map[string]interface{}:
+		pairs := make(map[HashKey]HashPair, len(v))
+		for key, element := range v {
+			k := &String{Value: key}
+			pairs[k.HashKey()] = HashPair{Key: k, Value: jsonToObject(element)}
+		}
+		return &Hash{Pairs: pairs}
+	}
+	return &Null{}
+}
+
+// StringifyJSON renders an object as JSON with sorted hash keys, with indent set each element is put on its own line.
+func StringifyJSON(obj Object, indent string) (string, error) {
+	value, err := objectToJSON(obj, "$")
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+// objectToJSON converts an object to a value encoding/json understands, path locates errors, e.g $.users[2].name
+func objectToJSON(obj Object, path string) (interface{}, error) {
+	switch o := obj.(type) {
+	case *Null:
+		return nil, nil
+	case *Boolean:
+		return o.Value, nil
+	case *String:
+		return o.Value, nil
+	case *Integer:
+		return json.Number(o.Inspect()), nil
+	case *BigInt:
+		return json.Number(o.Value.String()), nil
+	case *Decimal:
+		return json.Number(o.Inspect()), nil
+	case *DateTime:
+		return o.Inspect(), nil
+	case *Duration:
+		return o.Inspect(), nil
+	case *Float:
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			return nil, fmt.Errorf("cannot encode %s at %s", o.Inspect(), path)
+		}
+		// keep a fraction so 1.0 parses back as a Float
+		text, err := json.Marshal(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.ContainsAny(text, ".eE") {
+			text = append(text, ".0"...)
+		}
+		return json.Number(text), nil
+	case *Array:
+		elements := make([]interface{}, len(o.Elements))
+		for i, element := range o.Elements {
+			value, err := objectToJSON(element, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = value
+		}
+		return elements, nil
+	case *Hash:
+		pairs := make(map[string]interface{}, len(o.Pairs))
+		for _, pair := range o.Pairs {
+			var key string
+			switch k := pair.Key.(type) {
+			case *String:
+				key = k.Value
+			case *Integer, *BigInt, *Boolean:
+				key = k.Inspect()
+			default:
+				return nil, fmt.Errorf("cannot encode %s key %s at %s", k.Type(), k.Inspect(), path)
+			}
+			if _, ok := pairs[key]; ok {
+				return nil, fmt.Errorf("duplicate key \"%s\" at %s", key, path)
+			}
+			value, err := objectToJSON(pair.Value, path+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			pairs[key] = value
+		}
+		return pairs, nil
+	}
+	return nil, fmt.Errorf("cannot encode value of type %s at %s", obj.Type(), path)
+}
@@ -0,0 +1,105 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// DefaultMaxCallDepth keeps recursion well below the Go stack limit, past
+// which the process crashes instead of raising an error.
+const DefaultMaxCallDepth = 50000
+
+// Limits bounds the evaluations of a runtime, a zero field is no limit.
+type Limits struct {
+	// MaxSteps is the number of statements and loop iterations an evaluation may run.
+	MaxSteps int64
+	// MaxCallDepth is the number of calls in progress at once, across tasks.
+	// Zero is DefaultMaxCallDepth.
+	MaxCallDepth int64
+	// MaxCollectionSize is the length of the longest string or array
+	// operators like + and * may build.
+	MaxCollectionSize int
+}
+
+// evaluation is the state of the evaluation a runtime is running.
+type evaluation struct {
+	ctx   context.Context
+	steps atomic.Int64
+}
+
+// Start begins an evaluation that stops at its next step once ctx is done,
+// the step count starts again from zero.
+func (rt *Runtime) Start(ctx context.Context) {
+	rt.current.Store(&evaluation{ctx: ctx})
+}
+
+// Context is the context of the current evaluation, context.Background()
+// outside of one.
+func (rt *Runtime) Context() context.Context {
+	if eval := rt.or().current.Load(); eval != nil {
+		return eval.ctx
+	}
+	return context.Background()
+}
+
+// Done is closed when the context of the current evaluation is done.
+func (rt *Runtime) Done() <-chan struct{} {
+	return rt.Context().Done()
+}
+
+// Step counts a step of the current evaluation and returns an error when it
+// has to stop, because its context is done or it ran out of steps.
+func (rt *Runtime) Step() *Error {
+	rt = rt.or()
+	eval := rt.current.Load()
+	if eval == nil {
+		return nil
+	}
+	select {
+	case <-eval.ctx.Done():
+		return ContextError(eval.ctx.Err())
+	default:
+	}
+	if steps := eval.steps.Add(1); rt.Limits.MaxSteps > 0 && steps > rt.Limits.MaxSteps {
+		return &Error{Message: fmt.Sprintf("LimitError: step limit of %d exceeded", rt.Limits.MaxSteps)}
+	}
+	return nil
+}
+
+// ContextError is the error an evaluation stopped by its context raises.
+func ContextError(err error) *Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Message: "TimeoutError: evaluation timed out"}
+	}
+	return &Error{Message: "CancelledError: evaluation cancelled"}
+}
+
+// EnterCall counts a call in progress until ExitCall, it returns an error
+// instead when there are too many.
+func (rt *Runtime) EnterCall() *Error {
+	rt = rt.or()
+	max := rt.Limits.MaxCallDepth
+	if max == 0 {
+		max = DefaultMaxCallDepth
+	}
+	if rt.calls.Add(1) > max {
+		rt.calls.Add(-1)
+		return &Error{Message: fmt.Sprintf("RecursionError: maximum call depth of %d exceeded", max)}
+	}
+	return nil
+}
+
+func (rt *Runtime) ExitCall() {
+	rt.or().calls.Add(-1)
+}
+
+// CheckSize returns an error when a string or array of size n is over MaxCollectionSize.
+func (rt *Runtime) CheckSize(n int) *Error {
+	rt = rt.or()
+	if rt.Limits.MaxCollectionSize > 0 && n > rt.Limits.MaxCollectionSize {
+		return &Error{Message: fmt.Sprintf("MemoryError: size %d exceeds the collection size limit of %d", n, rt.Limits.MaxCollectionSize)}
+	}
+	return nil
+}
@@ -24,6 +24,19 @@ const (
 	HASH_OBJ         = "HASH"
 	SET_OBJ          = "SET"
 	MODULE_TYPE      = "MODULE"
+	REGEX_OBJ        = "REGEX"
+	RANDOM_OBJ       = "RANDOM"
+	BIGINT_OBJ       = "BIGINT"
+	DECIMAL_OBJ      = "DECIMAL"
+	FILE_OBJ         = "FILE"
+	CLI_OBJ          = "CLI"
+	RESPONSE_OBJ     = "RESPONSE"
+	SERVER_OBJ       = "SERVER"
+	REQUEST_OBJ      = "REQUEST"
+	DATETIME_OBJ     = "DATETIME"
+	DURATION_OBJ     = "DURATION"
+	TASK_OBJ         = "TASK"
+	CHANNEL_OBJ      = "CHANNEL"
 )
 
 type Object interface {
@@ -35,6 +48,9 @@ type Object interface {
 // String wraps a single value to a string.
 type String struct {
 	Value string
+	// Printed marks what print and println return, the text they printed,
+	// which the REPL does not echo.
+	Printed bool
 }
 
 func (s *String) Type() ObjectType { return STRING_OBJ }
@@ -57,7 +73,7 @@ type Integer struct {
 func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
 func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
 func (i *Integer) InvokeMethod(method string, env Environment, args ...Object) Object {
-	return intInvokables(method, i)
+	return intInvokables(method, i, args...)
 }
 
 type Float struct {
@@ -65,9 +81,9 @@ type Float struct {
 }
 
 func (f *Float) Type() ObjectType { return FLOAT_OBJ }
-func (f *Float) Inspect() string  { return fmt.Sprintf("%f", f.Value) }
+func (f *Float) Inspect() string  { return FormatFloat(f.Value) }
 func (f *Float) InvokeMethod(method string, env Environment, args ...Object) Object {
-	return nil
+	return floatInvokables(method, f, args...)
 }
 
 // Boolean wraps a single value to a boolean.
@@ -103,9 +119,12 @@ func (rv *ReturnValue) InvokeMethod(method string, env Environment, args ...Obje
 
 // Function wraps a block statement to a function.
 type Function struct {
+	// Name is the name a func statement defined, empty for a function literal.
+	Name       string
 	Parameters []*ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
+	Async      bool
 }
 
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
@@ -116,6 +135,9 @@ func (f *Function) Inspect() string {
 		params = append(params, p.String())
 	}
 
+	if f.Async {
+		out.WriteString("async ")
+	}
 	out.WriteString("fn")
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
@@ -245,6 +267,20 @@ type Hashable interface {
 // Error wraps a single value to an error.
 type Error struct {
 	Message string
+	// Traceback lists the calls the error propagated out of, innermost first,
+	// see FormatTraceback.
+	Traceback []Frame
+	// Omitted counts the outer calls left out of a traceback that grew too long.
+	Omitted int
+	// Exit marks the error process::Exit raises to stop the script, the host
+	// decides what to do with the status Code, see NewExit.
+	Exit bool
+	Code int
+}
+
+// NewExit returns the error that unwinds a script calling process::Exit(code).
+func NewExit(code int) *Error {
+	return &Error{Message: fmt.Sprintf("SystemExit: %d", code), Exit: true, Code: code}
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
@@ -255,8 +291,42 @@ func (e *Error) InvokeMethod(method string, env Environment, args ...Object) Obj
 
 type BuiltinFunction func(args ...Object) Object
 
+// CallFunction applies a callable object (a user function or a builtin) to args.
+// It is set by the evaluator so that builtins and object methods can call back
+// into esolang code, e.g. the replacer passed to `Regex.replace_fn`.
+var CallFunction func(fn Object, args ...Object) Object
+
+// RuntimeFunction is a builtin that prints or uses files, it is given the
+// runtime of the scope calling it.
+type RuntimeFunction func(rt *Runtime, args ...Object) Object
+
 type Builtin struct {
 	Fn BuiltinFunction
+	// RuntimeFn is called instead of Fn when set.
+	RuntimeFn RuntimeFunction
+	// runtime is the runtime the builtin was bound to, see Bind.
+	runtime *Runtime
+}
+
+// Bind returns the builtin using rt whatever runtime it is called with, so a
+// builtin a script passes as a callback keeps the script's runtime.
+func (b *Builtin) Bind(rt *Runtime) *Builtin {
+	if b.RuntimeFn == nil || rt == nil || rt == DefaultRuntime || b.runtime == rt {
+		return b
+	}
+	return &Builtin{Fn: b.Fn, RuntimeFn: b.RuntimeFn, runtime: rt}
+}
+
+// Call applies the builtin with rt, a nil rt is DefaultRuntime. A bound
+// builtin uses its own runtime instead.
+func (b *Builtin) Call(rt *Runtime, args ...Object) Object {
+	if b.RuntimeFn != nil {
+		if b.runtime != nil {
+			rt = b.runtime
+		}
+		return b.RuntimeFn(rt.or(), args...)
+	}
+	return b.Fn(args...)
 }
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
@@ -1,6 +1,11 @@
 package object
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestStringHashKey(t *testing.T) {
 	helloFirst := &String{Value: "Hello World"}
@@ -18,3 +23,168 @@ func TestStringHashKey(t *testing.T) {
 		t.Errorf("strings with different content have same hash keys")
 	}
 }
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{5, "5.0"},
+		{2.5, "2.5"},
+		{1.0 / 3.0, "0.3333333333333333"},
+		{-0.001, "-0.001"},
+		{1e21, "1e+21"},
+		{1e-7, "1e-07"},
+	}
+
+	for _, test := range tests {
+		if got := FormatFloat(test.input); got != test.expected {
+			t.Errorf("FormatFloat(%v) wrong. expected=%q, got=%q", test.input, test.expected, got)
+		}
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"19.99", "19.99"},
+		{"-0.5", "-0.5"},
+		{".25", "0.25"},
+		{"1.5e3", "1500"},
+		{"1.5e-3", "0.0015"},
+		{"+7", "7"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) returned error: %s", test.input, err)
+			continue
+		}
+		if d.Inspect() != test.expected {
+			t.Errorf("ParseDecimal(%q) wrong. expected=%q, got=%q", test.input, test.expected, d.Inspect())
+		}
+	}
+
+	for _, invalid := range []string{"", "abc", "1.2.3", "--1", "1e", "1e90000000", "1e-10001", "0." + strings.Repeat("0", MaxDecimalScale) + "1"} {
+		if _, err := ParseDecimal(invalid); err == nil {
+			t.Errorf("ParseDecimal(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestCliHelp(t *testing.T) {
+	app := NewCli("tool", "")
+	sub := app.InvokeMethod("command", Environment{}, &String{Value: "sync"}, &String{Value: "Sync files"})
+	sub.InvokeMethod("flag", Environment{}, &String{Value: "dry-run|n"}, &String{Value: "Only print changes"})
+
+	result, ok := app.InvokeMethod("parse", Environment{}, &Array{Elements: []Object{&String{Value: "sync"}, &String{Value: "--help"}, &String{Value: "--nope"}}}).(*Hash)
+	if !ok {
+		t.Fatalf("expected --help to return the parse result")
+	}
+	expected := "Usage: tool sync [options]\n\nSync files\n\nOptions:\n  -n, --dry-run  Only print changes\n  -h, --help     Show this help\n"
+	help := result.Pairs[(&String{Value: "help"}).HashKey()].Value
+	if help.Inspect() != expected {
+		t.Errorf("wrong usage. expected=%q, got=%q", expected, help.Inspect())
+	}
+}
+
+func TestNewGoFunction(t *testing.T) {
+	repeat, err := NewGoFunction("repeat", func(name string, n int) (map[string]any, error) {
+		if n < 0 {
+			return nil, errors.New("ValueError: n must not be negative")
+		}
+		if n == 0 {
+			return nil, errors.New("nothing to repeat")
+		}
+		return map[string]any{"text": strings.Repeat(name, n)}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, _ := NewGoFunction("sum", func(scale float64, values ...int8) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += float64(v)
+		}
+		return total * scale
+	})
+	names, _ := NewGoFunction("names", func(people []map[string]string) []string {
+		var names []string
+		for _, p := range people {
+			names = append(names, p["name"])
+		}
+		return names
+	})
+	open, _ := NewGoFunction("open", func(path string) error {
+		_, err := os.Open(path)
+		return err
+	})
+
+	str := func(s string) Object { return &String{Value: s} }
+	num := func(n int64) Object { return &Integer{Value: n} }
+	person := func(name string) Object {
+		return &Hash{Pairs: map[HashKey]HashPair{str("name").(*String).HashKey(): {Key: str("name"), Value: str(name)}}}
+	}
+	tests := []struct {
+		fn       *Builtin
+		args     []Object
+		expected string
+	}{
+		{repeat, []Object{str("ab"), num(2)}, `{"text": "abab"}`},
+		{repeat, []Object{str("ab")}, "ERROR: TypeError: repeat() takes exactly 2 argument (1 given)"},
+		{repeat, []Object{num(2), num(2)}, "ERROR: TypeError: repeat() expected argument #1 to be `STRING` got `INTEGER`"},
+		{repeat, []Object{str("ab"), num(-1)}, "ERROR: ValueError: n must not be negative"},
+		{repeat, []Object{str("ab"), num(0)}, "ERROR: RuntimeError: nothing to repeat"},
+		{sum, []Object{&Float{Value: 0.5}, num(1), num(2)}, "1.5"},
+		{sum, []Object{num(2)}, "0.0"},
+		{sum, []Object{num(1), num(1000)}, "ERROR: TypeError: sum() argument #2 1000 does not fit in int8"},
+		{sum, []Object{num(1), str("x")}, "ERROR: TypeError: sum() expected argument #2 to be `INTEGER` got `STRING`"},
+		{sum, []Object{str("x")}, "ERROR: TypeError: sum() expected argument #1 to be `FLOAT` or `INTEGER` got `STRING`"},
+		{names, []Object{&Array{Elements: []Object{person("ada"), person("bob")}}}, "[ada, bob]"},
+		{names, []Object{&Array{Elements: []Object{person("ada"), num(1)}}}, "ERROR: TypeError: names() argument #1 element #2 expected `HASH` got `INTEGER`"},
+		{open, []Object{str("/no/such/file")}, "ERROR: FileNotFoundError: open /no/such/file: no such file or directory"},
+	}
+	for _, tt := range tests {
+		if got := tt.fn.Call(nil, tt.args...).Inspect(); got != tt.expected {
+			t.Errorf("wrong result. expected=%q, got=%q", tt.expected, got)
+		}
+	}
+
+	for _, bad := range []any{42, func(c chan int) {}, func() (int, int) { return 0, 0 }, func(m map[int]string) {}} {
+		if _, err := NewGoFunction("bad", bad); err == nil {
+			t.Errorf("expected %T to be rejected", bad)
+		}
+	}
+}
+
+func TestFormatTraceback(t *testing.T) {
+	raised := &Error{Message: "lib.eso:2:5: ValueError: bad"}
+	inner := raised.WithFrame(Frame{Function: "check", File: "lib.eso", Line: 7, Column: 10})
+	if len(raised.Traceback) != 0 {
+		t.Errorf("WithFrame changed the original error")
+	}
+
+	err := inner
+	for i := 0; i < maxTracebackFrames+5; i++ {
+		err = err.WithFrame(Frame{Function: "f", File: "main.eso", Line: i + 1, Column: 1})
+	}
+	if len(err.Traceback) != maxTracebackFrames || err.Omitted != 6 {
+		t.Errorf("wrong traceback length. got=%d omitted=%d", len(err.Traceback), err.Omitted)
+	}
+
+	expected := `Traceback (most recent call last):
+  File "main.eso", line 1, in <module>
+  File "lib.eso", line 7, in f
+  File "lib.eso", line 2, in check
+ValueError: bad`
+	short := inner.WithFrame(Frame{Function: "f", File: "main.eso", Line: 1, Column: 1})
+	if got := short.FormatTraceback(); got != expected {
+		t.Errorf("wrong traceback. expected=\n%s\ngot=\n%s", expected, got)
+	}
+	if got := err.FormatTraceback(); !strings.HasPrefix(got, "Traceback (most recent call last):\n  [6 more calls]\n") {
+		t.Errorf("omitted calls should be counted, got=\n%s", got)
+	}
+}
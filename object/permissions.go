@@ -0,0 +1,143 @@
+package object
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// AllPaths in Permissions.Read or Permissions.Write allows every path.
+const AllPaths = "*"
+
+// Permissions is what the scripts of a sandboxed runtime may do outside of the
+// interpreter, anything not allowed is denied. Importing a module reads its
+// file, the standard library and the modules of a built program excepted.
+type Permissions struct {
+	// Read and Write list the paths scripts may read and change, a directory
+	// allows everything below it.
+	Read  []string
+	Write []string
+	// Net allows HTTP requests and servers.
+	Net bool
+	// Run allows running commands, with backticks or Spawn, and exiting or
+	// changing the working directory of the process.
+	Run bool
+	// Env allows reading and changing environment variables, and reading the
+	// working directory and the id of the process.
+	Env bool
+}
+
+// PermissionError is returned when the permissions of a runtime deny an
+// operation, it matches fs.ErrPermission.
+type PermissionError struct {
+	// Access is the denied capability: "read", "write", "net", "run" or "env".
+	Access string
+	// Path is the denied path for "read" and "write".
+	Path string
+}
+
+func (e *PermissionError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("%s access to '%s' denied", e.Access, e.Path)
+	}
+	return fmt.Sprintf("%s access denied", e.Access)
+}
+
+func (e *PermissionError) Is(target error) bool {
+	return target == fs.ErrPermission
+}
+
+// CheckRead returns a *PermissionError when the permissions of rt do not allow
+// reading name.
+func (rt *Runtime) CheckRead(name string) error {
+	rt = rt.or()
+	if rt.Permissions == nil || rt.allowsPath(rt.Permissions.Read, name) {
+		return nil
+	}
+	return &PermissionError{Access: "read", Path: name}
+}
+
+// CheckNet returns a *PermissionError when the permissions of rt do not allow
+// network access.
+func (rt *Runtime) CheckNet() error {
+	return rt.check("net", func(p *Permissions) bool { return p.Net })
+}
+
+// CheckRun returns a *PermissionError when the permissions of rt do not allow
+// running commands.
+func (rt *Runtime) CheckRun() error {
+	return rt.check("run", func(p *Permissions) bool { return p.Run })
+}
+
+// CheckEnv returns a *PermissionError when the permissions of rt do not allow
+// environment variables.
+func (rt *Runtime) CheckEnv() error {
+	return rt.check("env", func(p *Permissions) bool { return p.Env })
+}
+
+func (rt *Runtime) check(access string, allowed func(*Permissions) bool) error {
+	rt = rt.or()
+	if rt.Permissions == nil || allowed(rt.Permissions) {
+		return nil
+	}
+	return &PermissionError{Access: access}
+}
+
+// allowsPath reports whether name is one of paths or below one of them. On the
+// host filesystem symbolic links are followed, so a link cannot lead out of an
+// allowed directory.
+func (rt *Runtime) allowsPath(paths []string, name string) bool {
+	target, ok := rt.permissionPath(name)
+	if !ok {
+		return false
+	}
+	for _, allowed := range paths {
+		if allowed == AllPaths {
+			return true
+		}
+		dir, ok := rt.permissionPath(allowed)
+		if !ok {
+			continue
+		}
+		if rt.FS != nil {
+			if dir == "." || target == dir || strings.HasPrefix(target, dir+"/") {
+				return true
+			}
+			continue
+		}
+		rel, err := filepath.Rel(dir, target)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionPath is name as the permissions compare it: a name of rt.FS, or an
+// absolute host path with its symbolic links resolved.
+func (rt *Runtime) permissionPath(name string) (string, bool) {
+	if rt.FS != nil {
+		clean, err := fsName("check", name)
+		return clean, err == nil
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", false
+	}
+	return resolveLinks(abs), true
+}
+
+// resolveLinks resolves the symbolic links of the longest part of path that
+// exists, the rest of it does not exist yet so it cannot be a link.
+func resolveLinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	dir, base := filepath.Split(path)
+	dir = filepath.Clean(dir)
+	if dir == path {
+		return path
+	}
+	return filepath.Join(resolveLinks(dir), base)
+}
@@ -0,0 +1,113 @@
+package object
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Random is a pseudo-random number generator.
+// Generators created with the same seed produce the same sequence.
+type Random struct {
+	Seed   int64
+	Source *rand.Rand
+}
+
+func NewRandom(seed int64) *Random {
+	return &Random{Seed: seed, Source: rand.New(rand.NewSource(seed))}
+}
+
+func (r *Random) Type() ObjectType { return RANDOM_OBJ }
+func (r *Random) Inspect() string  { return fmt.Sprintf("<random seed=%d>", r.Seed) }
+func (r *Random) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return randomInvokables(method, r, args...)
+}
+
+// uint64n returns a uniform integer in [0, max].
+func (r *Random) uint64n(max uint64) uint64 {
+	if max < math.MaxInt64 {
+		return uint64(r.Source.Int63n(int64(max) + 1))
+	}
+	if max == math.MaxUint64 {
+		return r.Source.Uint64()
+	}
+	// reject the values past the last whole multiple of max+1 so none is favoured
+	n := max + 1
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := r.Source.Uint64(); v < limit {
+			return v % n
+		}
+	}
+}
+
+func randomInvokables(method string, r *Random, args ...Object) Object {
+	name := "Random." + method
+	switch method {
+	case "int":
+		/*
+			int()         a non-negative integer
+			int(n)        an integer in [0, n)
+			int(min, max) an integer in [min, max]
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 2),
+			WithTypes(INTEGER_OBJ, INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		switch len(args) {
+		case 0:
+			return &Integer{Value: r.Source.Int63()}
+		case 1:
+			n := args[0].(*Integer).Value
+			if n <= 0 {
+				return newError("ValueError: %s() bound must be positive, got %d", name, n)
+			}
+			return &Integer{Value: r.Source.Int63n(n)}
+		default:
+			low, high := args[0].(*Integer).Value, args[1].(*Integer).Value
+			if low > high {
+				return newError("ValueError: %s() lower bound %d is greater than upper bound %d", name, low, high)
+			}
+			// the span is computed unsigned, it does not fit an int64 when the bounds are far apart
+			return &Integer{Value: int64(uint64(low) + r.uint64n(uint64(high)-uint64(low)))}
+		}
+
+	case "float":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Float{Value: r.Source.Float64()}
+
+	case "choice":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(ARRAY_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		elements := args[0].(*Array).Elements
+		if len(elements) == 0 {
+			return newError("ValueError: %s() cannot choose from an empty array", name)
+		}
+		return elements[r.Source.Intn(len(elements))]
+
+	case "shuffle":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(ARRAY_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		elements := append([]Object{}, args[0].(*Array).Elements...)
+		r.Source.Shuffle(len(elements), func(i, j int) {
+			elements[i], elements[j] = elements[j], elements[i]
+		})
+		return &Array{Elements: elements}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package object
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Regex wraps a compiled regular expression.
+type Regex struct {
+	Value *regexp.Regexp
+}
+
+func (r *Regex) Type() ObjectType { return REGEX_OBJ }
+func (r *Regex) Inspect() string  { return fmt.Sprintf("re\"%s\"", r.Value.String()) }
+func (r *Regex) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return regexInvokables(method, r, args...)
+}
+
+func regexInvokables(method string, r *Regex, args ...Object) Object {
+	name := "Regex." + method
+	switch method {
+	case "match":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: r.Value.MatchString(args[0].(*String).Value)}
+
+	case "find_all":
+		/*
+			find_all(s)
+			find_all(s, limit)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(1, 2),
+			WithTypes(STRING_OBJ, INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		limit := -1
+		if len(args) == 2 {
+			limit = int(args[1].(*Integer).Value)
+		}
+		return stringsToArray(r.Value.FindAllString(args[0].(*String).Value, limit))
+
+	case "captures":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return regexCaptures(r, args[0].(*String).Value)
+
+	case "replace":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ, STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: r.Value.ReplaceAllString(args[0].(*String).Value, args[1].(*String).Value)}
+
+	case "replace_fn":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return regexReplaceFn(r, args[0].(*String).Value, args[1])
+
+	case "split":
+		/*
+			split(s)
+			split(s, limit)
+		*/
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(1, 2),
+			WithTypes(STRING_OBJ, INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		limit := -1
+		if len(args) == 2 {
+			limit = int(args[1].(*Integer).Value)
+		}
+		return stringsToArray(r.Value.Split(args[0].(*String).Value, limit))
+
+	case "pattern", "to_string":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: r.Value.String()}
+	}
+	return nil
+}
+
+// regexCaptures returns a hash of the named groups of the first match in s,
+// or null when the pattern does not match.
+func regexCaptures(r *Regex, s string) Object {
+	match := r.Value.FindStringSubmatch(s)
+	if match == nil {
+		return &Null{}
+	}
+	pairs := make(map[HashKey]HashPair)
+	for i, groupName := range r.Value.SubexpNames() {
+		if i == 0 || groupName == "" {
+			continue
+		}
+		key := &String{Value: groupName}
+		pairs[key.HashKey()] = HashPair{Key: key, Value: &String{Value: match[i]}}
+	}
+	return &Hash{Pairs: pairs}
+}
+
+// regexReplaceFn replaces every match in s with the result of calling fn on the match.
+func regexReplaceFn(r *Regex, s string, fn Object) Object {
+	if fn.Type() != FUNCTION_OBJ && fn.Type() != BUILTIN_OBJ {
+		return newError("TypeError: Regex.replace_fn() expected argument #2 to be `%s` got `%s`", FUNCTION_OBJ, fn.Type())
+	}
+
+	var failure Object
+	replaced := r.Value.ReplaceAllStringFunc(s, func(match string) string {
+		if failure != nil {
+			return match
+		}
+		result := CallFunction(fn, &String{Value: match})
+		if result == nil {
+			return ""
+		}
+		if result.Type() == ERROR_OBJ {
+			failure = result
+			return match
+		}
+		return result.Inspect()
+	})
+	if failure != nil {
+		return failure
+	}
+	return &String{Value: replaced}
+}
+
+func stringsToArray(values []string) *Array {
+	elements := make([]Object, len(values))
+	for i, v := range values {
+		elements[i] = &String{Value: v}
+	}
+	return &Array{Elements: elements}
+}
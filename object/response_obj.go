@@ -0,0 +1,112 @@
+package object
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Response is the result of an HTTP request.
+type Response struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+func (r *Response) Type() ObjectType { return RESPONSE_OBJ }
+func (r *Response) Inspect() string {
+	return fmt.Sprintf("<response %s %s %d>", r.Method, r.URL, r.StatusCode)
+}
+func (r *Response) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return responseInvokables(method, r, args...)
+}
+
+// HeadersHash returns the headers keyed by their lower case name, repeated headers are joined with ", ".
+func HeadersHash(header http.Header) *Hash {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make(map[HashKey]HashPair, len(header))
+	for _, name := range names {
+		key := &String{Value: strings.ToLower(name)}
+		pairs[key.HashKey()] = HashPair{Key: key, Value: &String{Value: strings.Join(header.Values(name), ", ")}}
+	}
+	return &Hash{Pairs: pairs}
+}
+
+func responseInvokables(method string, r *Response, args ...Object) Object {
+	name := "Response." + method
+	switch method {
+	case "status":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: int64(r.StatusCode)}
+
+	case "status_text":
+		// the reason phrase, e.g "Not Found"
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: strings.TrimSpace(strings.TrimPrefix(r.Status, fmt.Sprint(r.StatusCode)))}
+
+	case "ok":
+		// ok is true for 2xx status codes
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: r.StatusCode >= 200 && r.StatusCode < 300}
+
+	case "headers":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return HeadersHash(r.Header)
+
+	case "header":
+		// header(name) a single header, matched case-insensitively, or null
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		values := r.Header.Values(args[0].(*String).Value)
+		if len(values) == 0 {
+			return &Null{}
+		}
+		return &String{Value: strings.Join(values, ", ")}
+
+	case "text":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: string(r.Body)}
+
+	case "json":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		value, err := ParseJSON(string(r.Body))
+		if err != nil {
+			return newError("JSONError: %s() body of %s %s: %s", name, r.Method, r.URL, err)
+		}
+		return value
+
+	case "url":
+		// the final URL, after redirects
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: r.URL}
+	}
+	return nil
+}
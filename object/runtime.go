@@ -0,0 +1,323 @@
+package object
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Runtime is what the host provides to the scripts it runs: where they print,
+// the files they can reach and the modules they imported. A scope uses the
+// runtime of its outermost environment, DefaultRuntime when none was set.
+type Runtime struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	// FS, when set, is the only filesystem scripts see and it is read-only:
+	// file builtins and imports read from it and writes fail.
+	FS fs.FS
+	// Modules caches the modules imported under this runtime.
+	Modules *Modules
+	Limits  Limits
+	// Permissions, when set, sandboxes the scripts: they may only do what it allows.
+	Permissions *Permissions
+	// Args is the path of the running script followed by its arguments, what
+	// process::Args() returns.
+	Args []string
+	// RaiseOnCommandFailure makes backtick commands that exit with a non-zero
+	// status return a CommandError instead of a result hash.
+	RaiseOnCommandFailure atomic.Bool
+
+	current atomic.Pointer[evaluation]
+	calls   atomic.Int64
+}
+
+// NewRuntime returns a runtime with an empty module cache, a nil writer discards
+// what is written to it and a nil fsys is the host filesystem.
+func NewRuntime(stdout, stderr io.Writer, fsys fs.FS) *Runtime {
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	return &Runtime{Stdout: stdout, Stderr: stderr, FS: fsys, Modules: NewModules()}
+}
+
+// DefaultRuntime is the process' own stdout, stderr, filesystem and arguments,
+// used by the CLI and the REPL. The CLI replaces its Args before running a script.
+var DefaultRuntime = NewRuntime(os.Stdout, os.Stderr, nil)
+
+func init() {
+	DefaultRuntime.Args = os.Args
+}
+
+// or returns rt, or DefaultRuntime when rt is nil.
+func (rt *Runtime) or() *Runtime {
+	if rt == nil {
+		return DefaultRuntime
+	}
+	return rt
+}
+
+// fsName turns a script's path into a name of rt.FS: slash separated and
+// relative to its root, "./data/a.txt" and "/data/a.txt" are both "data/a.txt".
+func fsName(op, name string) (string, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	if clean == "" {
+		clean = "."
+	}
+	if !fs.ValidPath(clean) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return clean, nil
+}
+
+// Open opens a file for reading.
+func (rt *Runtime) Open(name string) (fs.File, error) {
+	rt = rt.or()
+	if err := rt.CheckRead(name); err != nil {
+		return nil, err
+	}
+	if rt.FS == nil {
+		return os.Open(name)
+	}
+	clean, err := fsName("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return rt.FS.Open(clean)
+}
+
+// OpenFile opens a file with the flags of os.OpenFile, files of a read-only
+// FS can only be opened with os.O_RDONLY.
+func (rt *Runtime) OpenFile(name string, flag int, perm fs.FileMode) (io.ReadWriteCloser, error) {
+	rt = rt.or()
+	if flag&(os.O_RDONLY|os.O_WRONLY|os.O_RDWR) != os.O_WRONLY {
+		if err := rt.CheckRead(name); err != nil {
+			return nil, err
+		}
+	}
+	if flag != os.O_RDONLY {
+		if err := rt.CheckWrite("open", name); err != nil {
+			return nil, err
+		}
+	}
+	if rt.FS == nil {
+		return os.OpenFile(name, flag, perm)
+	}
+	f, err := rt.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return readOnlyFile{f, name}, nil
+}
+
+// readOnlyFile is a file of a read-only FS used where a file can also be written.
+type readOnlyFile struct {
+	fs.File
+	name string
+}
+
+func (f readOnlyFile) Write(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "write", Path: f.name, Err: syscall.EROFS}
+}
+
+func (rt *Runtime) ReadFile(name string) ([]byte, error) {
+	rt = rt.or()
+	if err := rt.CheckRead(name); err != nil {
+		return nil, err
+	}
+	if rt.FS == nil {
+		return os.ReadFile(name)
+	}
+	clean, err := fsName("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(rt.FS, clean)
+}
+
+func (rt *Runtime) Stat(name string) (fs.FileInfo, error) {
+	rt = rt.or()
+	if err := rt.CheckRead(name); err != nil {
+		return nil, err
+	}
+	if rt.FS == nil {
+		return os.Stat(name)
+	}
+	clean, err := fsName("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(rt.FS, clean)
+}
+
+// ReadDir returns the entries of a directory sorted by name.
+func (rt *Runtime) ReadDir(name string) ([]fs.DirEntry, error) {
+	rt = rt.or()
+	if err := rt.CheckRead(name); err != nil {
+		return nil, err
+	}
+	if rt.FS == nil {
+		return os.ReadDir(name)
+	}
+	clean, err := fsName("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadDir(rt.FS, clean)
+}
+
+// WalkDir walks the tree below root, root itself is visited first.
+func (rt *Runtime) WalkDir(root string, fn fs.WalkDirFunc) error {
+	rt = rt.or()
+	if err := rt.CheckRead(root); err != nil {
+		return err
+	}
+	if rt.FS == nil {
+		return filepath.WalkDir(root, fn)
+	}
+	clean, err := fsName("walk", root)
+	if err != nil {
+		return err
+	}
+	return fs.WalkDir(rt.FS, clean, fn)
+}
+
+// Glob returns the paths matching pattern, leaving out those scripts may not read.
+func (rt *Runtime) Glob(pattern string) ([]string, error) {
+	rt = rt.or()
+	var matches []string
+	var err error
+	if rt.FS == nil {
+		matches, err = filepath.Glob(pattern)
+	} else {
+		var clean string
+		if clean, err = fsName("glob", pattern); err == nil {
+			matches, err = fs.Glob(rt.FS, clean)
+		}
+	}
+	if err != nil || rt.Permissions == nil {
+		return matches, err
+	}
+	readable := matches[:0]
+	for _, match := range matches {
+		if rt.CheckRead(match) == nil {
+			readable = append(readable, match)
+		}
+	}
+	return readable, nil
+}
+
+// CheckWrite returns an error when name cannot be changed because the
+// filesystem of the runtime is read-only, op names the operation in it, or a
+// *PermissionError when the permissions of the runtime do not allow it.
+func (rt *Runtime) CheckWrite(op, name string) error {
+	rt = rt.or()
+	if rt.FS != nil {
+		return &fs.PathError{Op: op, Path: name, Err: syscall.EROFS}
+	}
+	if rt.Permissions != nil && !rt.allowsPath(rt.Permissions.Write, name) {
+		return &PermissionError{Access: "write", Path: name}
+	}
+	return nil
+}
+
+// moduleEntry is a module that has been, or is being, loaded. done is closed
+// once attrs is set.
+type moduleEntry struct {
+	done  chan struct{}
+	attrs Object
+}
+
+// Modules caches each module by its resolved path, or its name for the stdlib,
+// so a module is evaluated once however many times it is imported.
+type Modules struct {
+	mu      sync.Mutex
+	entries map[string]*moduleEntry
+	// importedBy links a module being loaded to the module that imported it,
+	// following it from the importer tells a cycle apart from a module that
+	// another task happens to be loading.
+	importedBy map[string]string
+}
+
+func NewModules() *Modules {
+	return &Modules{entries: map[string]*moduleEntry{}, importedBy: map[string]string{}}
+}
+
+// Reset empties the cache, the next import of each module evaluates it again.
+func (m *Modules) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = map[string]*moduleEntry{}
+	m.importedBy = map[string]string{}
+}
+
+// Load returns the module key imported from importer, calling load the first
+// time it is imported and waiting when another task is loading it. When the
+// import would be circular it returns the chain of imports, in import order,
+// instead. A module whose load returns an error is loaded again next time.
+func (m *Modules) Load(importer, key string, load func() Object) (Object, []string) {
+	m.mu.Lock()
+	if entry, ok := m.entries[key]; ok {
+		select {
+		case <-entry.done:
+			m.mu.Unlock()
+			return entry.attrs, nil
+		default:
+		}
+		if chain := m.importChain(importer, key); chain != nil {
+			m.mu.Unlock()
+			return nil, chain
+		}
+		// another task is loading it
+		m.mu.Unlock()
+		<-entry.done
+		return entry.attrs, nil
+	}
+	entry := &moduleEntry{done: make(chan struct{})}
+	m.entries[key] = entry
+	m.importedBy[key] = importer
+	m.mu.Unlock()
+
+	entry.attrs = newError("ImportError: module '%s' did not finish loading", key)
+	defer func() {
+		m.mu.Lock()
+		delete(m.importedBy, key)
+		if _, failed := entry.attrs.(*Error); failed {
+			delete(m.entries, key)
+		}
+		m.mu.Unlock()
+		close(entry.done)
+	}()
+	entry.attrs = load()
+	return entry.attrs, nil
+}
+
+// importChain returns the imports leading from key back to key through
+// importer, or nil when importer was not imported by key.
+func (m *Modules) importChain(importer, key string) []string {
+	chain := []string{key}
+	for current := importer; ; {
+		chain = append(chain, current)
+		if current == key {
+			break
+		}
+		next, ok := m.importedBy[current]
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	// reverse so the chain reads in import order
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
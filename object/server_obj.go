@@ -0,0 +1,170 @@
+package object
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Router holds the routes of a Server and serves them, the builtins implement
+// it with echo.
+type Router interface {
+	http.Handler
+	// Route registers handler for requests with method to path, method "ANY"
+	// matches every method.
+	Route(method, path string, handler Object)
+	// Routes returns the number of registered routes.
+	Routes() int
+	// Listen serves the routes on port until the process stops.
+	Listen(port int) error
+}
+
+// Server routes HTTP requests to esolang handler functions.
+type Server struct {
+	Router Router
+}
+
+func (s *Server) Type() ObjectType { return SERVER_OBJ }
+func (s *Server) Inspect() string  { return fmt.Sprintf("<server routes=%d>", s.Router.Routes()) }
+func (s *Server) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return serverInvokables(method, s, args...)
+}
+
+// Handler exposes the routes as an http.Handler, e.g. for httptest.
+func (s *Server) Handler() http.Handler {
+	return s.Router
+}
+
+func serverInvokables(method string, s *Server, args ...Object) Object {
+	name := "Server." + method
+	switch method {
+	case "get", "post", "put", "patch", "delete", "head", "options", "any":
+		/*
+			get(path, handler) registers handler for GET requests to path.
+			path segments starting with ":" are parameters and "*" matches the rest, e.g "/users/:id".
+			handler is called with a Request and returns the response (see reply).
+		*/
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(2),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if args[1].Type() != FUNCTION_OBJ && args[1].Type() != BUILTIN_OBJ {
+			return newError("TypeError: %s() handler must be a FUNCTION, got %s", name, args[1].Type())
+		}
+		path := args[0].(*String).Value
+		if !strings.HasPrefix(path, "/") {
+			return newError("ValueError: %s() path must start with '/', got '%s'", name, path)
+		}
+		s.Router.Route(strings.ToUpper(method), path, args[1])
+		return s
+
+	case "listen":
+		// listen(port) serves until the process stops
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if err := s.Router.Listen(int(args[0].(*Integer).Value)); err != nil {
+			return newError("IOError: %s", err)
+		}
+		return &Null{}
+	}
+	return nil
+}
+
+// NewReply builds the Response a handler returns.
+func NewReply(status int, contentType string, body []byte) *Response {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return &Response{StatusCode: status, Status: fmt.Sprintf("%d %s", status, http.StatusText(status)), Header: header, Body: body}
+}
+
+// Request is an incoming request passed to a server handler.
+type Request struct {
+	Request *http.Request
+	Params  map[string]string
+	Body    []byte
+}
+
+func (r *Request) Type() ObjectType { return REQUEST_OBJ }
+func (r *Request) Inspect() string {
+	return fmt.Sprintf("<request %s %s>", r.Request.Method, r.Request.URL.RequestURI())
+}
+func (r *Request) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return requestInvokables(method, r, args...)
+}
+
+func stringHash(values map[string]string) *Hash {
+	pairs := make(map[HashKey]HashPair, len(values))
+	for key, value := range values {
+		k := &String{Value: key}
+		pairs[k.HashKey()] = HashPair{Key: k, Value: &String{Value: value}}
+	}
+	return &Hash{Pairs: pairs}
+}
+
+func requestInvokables(method string, r *Request, args ...Object) Object {
+	name := "Request." + method
+	switch method {
+	case "method", "path", "url", "params", "query", "headers", "body", "text", "json":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+	case "param", "header":
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+	}
+
+	switch method {
+	case "method":
+		return &String{Value: r.Request.Method}
+	case "path":
+		return &String{Value: r.Request.URL.Path}
+	case "url":
+		return &String{Value: r.Request.URL.RequestURI()}
+	case "params":
+		return stringHash(r.Params)
+	case "param":
+		if value, ok := r.Params[args[0].(*String).Value]; ok {
+			return &String{Value: value}
+		}
+		return &Null{}
+	case "query":
+		// repeated parameters keep their first value
+		query := make(map[string]string)
+		for key, values := range r.Request.URL.Query() {
+			query[key] = values[0]
+		}
+		return stringHash(query)
+	case "headers":
+		return HeadersHash(r.Request.Header)
+	case "header":
+		values := r.Request.Header.Values(args[0].(*String).Value)
+		if len(values) == 0 {
+			return &Null{}
+		}
+		return &String{Value: strings.Join(values, ", ")}
+	case "body", "text":
+		return &String{Value: string(r.Body)}
+	case "json":
+		value, err := ParseJSON(string(r.Body))
+		if err != nil {
+			return newError("JSONError: %s() %s", name, err)
+		}
+		return value
+	}
+	return nil
+}
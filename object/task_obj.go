@@ -0,0 +1,141 @@
+package object
+
+import (
+	"reflect"
+	"time"
+)
+
+// Task is a function running concurrently, created with `spawn`.
+type Task struct {
+	done   chan struct{}
+	result Object
+}
+
+// NewTask starts run on its own goroutine. A panic inside run becomes the task's error.
+func NewTask(run func() Object) *Task {
+	task := &Task{done: make(chan struct{})}
+	go func() {
+		defer close(task.done)
+		defer func() {
+			if r := recover(); r != nil {
+				task.result = newError("RuntimeError: task panicked: %v", r)
+			}
+		}()
+		task.result = run()
+		if inner, ok := task.result.(*Task); ok {
+			// a task finishing with another task, e.g spawning an async function, settles with it
+			<-inner.done
+			task.result = inner.result
+		}
+		if task.result == nil {
+			task.result = &Null{}
+		}
+	}()
+	return task
+}
+
+func (t *Task) Type() ObjectType { return TASK_OBJ }
+func (t *Task) Inspect() string {
+	if t.Done() {
+		return "<task done>"
+	}
+	return "<task running>"
+}
+func (t *Task) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return taskInvokables(method, t, env.Runtime(), args...)
+}
+
+// Done reports whether the task has finished.
+func (t *Task) Done() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the task finishes and returns its result, errors included,
+// or until the evaluation of rt stops and returns its error.
+func (t *Task) Wait(rt *Runtime) Object {
+	select {
+	case <-t.done:
+		return t.result
+	case <-rt.Done():
+		return ContextError(rt.Context().Err())
+	}
+}
+
+// WaitAll waits for every task and returns their results in order. The first
+// error, in the order of tasks, is returned instead, once all have finished.
+// The evaluation of rt stopping ends the wait with its error.
+func WaitAll(rt *Runtime, tasks []*Task) Object {
+	results := make([]Object, len(tasks))
+	var firstErr Object
+	for i, t := range tasks {
+		select {
+		case <-t.done:
+		case <-rt.Done():
+			return ContextError(rt.Context().Err())
+		}
+		results[i] = t.result
+		if _, ok := results[i].(*Error); ok && firstErr == nil {
+			firstErr = results[i]
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return &Array{Elements: results}
+}
+
+// WaitFirst returns the result of whichever task finishes first, or the error
+// of the evaluation of rt when it stops before any does.
+func WaitFirst(rt *Runtime, tasks []*Task) Object {
+	cases := make([]reflect.SelectCase, len(tasks), len(tasks)+1)
+	for i, t := range tasks {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(t.done)}
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(rt.Done())})
+	chosen, _, _ := reflect.Select(cases)
+	if chosen == len(tasks) {
+		return ContextError(rt.Context().Err())
+	}
+	return tasks[chosen].result
+}
+
+func taskInvokables(method string, t *Task, rt *Runtime, args ...Object) Object {
+	name := "Task." + method
+	switch method {
+	case "await":
+		// await([timeout]) waits for the result, an error in the task is raised here
+		if err := CheckTypings(
+			name, args,
+			RangeOfArgs(0, 1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if len(args) == 0 {
+			return t.Wait(rt)
+		}
+		timeout, errObj := ToDuration(args[0])
+		if errObj != nil {
+			return errObj
+		}
+		select {
+		case <-t.done:
+			return t.result
+		case <-time.After(timeout):
+			return newError("TimeoutError: task did not finish within %s", timeout)
+		case <-rt.Done():
+			return ContextError(rt.Context().Err())
+		}
+
+	case "done":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Boolean{Value: t.Done()}
+	}
+	return nil
+}
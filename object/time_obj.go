@@ -0,0 +1,289 @@
+package object
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	// time zones work even on systems without a zoneinfo database
+	_ "time/tzdata"
+)
+
+// DateTime is an instant in time with a location.
+type DateTime struct {
+	Value time.Time
+}
+
+func (dt *DateTime) Type() ObjectType { return DATETIME_OBJ }
+func (dt *DateTime) Inspect() string  { return dt.Value.Format(time.RFC3339Nano) }
+func (dt *DateTime) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return dateTimeInvokables(method, dt, args...)
+}
+
+// Duration is the elapsed time between two instants, with nanosecond precision.
+type Duration struct {
+	Value time.Duration
+}
+
+func (d *Duration) Type() ObjectType { return DURATION_OBJ }
+func (d *Duration) Inspect() string  { return d.Value.String() }
+func (d *Duration) InvokeMethod(method string, env Environment, args ...Object) Object {
+	return durationInvokables(method, d, args...)
+}
+
+// timeLayouts are the named layouts accepted wherever a layout is expected.
+var timeLayouts = map[string]string{
+	"ISO":         time.RFC3339,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC822":      time.RFC822,
+	"ANSIC":       time.ANSIC,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// TimeLayout resolves a named layout, anything else is used as a Go reference
+// layout written with the date Mon Jan 2 15:04:05 MST 2006.
+func TimeLayout(layout string) string {
+	if named, ok := timeLayouts[layout]; ok {
+		return named
+	}
+	return layout
+}
+
+// LoadLocation accepts "UTC", "Local" or an IANA name such as "Europe/Paris".
+func LoadLocation(name string) (*time.Location, Object) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, newError("ValueError: unknown time zone '%s'", name)
+	}
+	return location, nil
+}
+
+// ToDuration accepts a Duration, a number of milliseconds or a string such as "1h30m".
+func ToDuration(obj Object) (time.Duration, Object) {
+	switch obj := obj.(type) {
+	case *Duration:
+		return obj.Value, nil
+	case *Integer:
+		return time.Duration(obj.Value) * time.Millisecond, nil
+	case *Float:
+		return time.Duration(obj.Value * float64(time.Millisecond)), nil
+	case *String:
+		d, err := time.ParseDuration(obj.Value)
+		if err != nil {
+			return 0, newError("ValueError: invalid duration '%s', expected e.g 1h30m, 250ms", obj.Value)
+		}
+		return d, nil
+	}
+	return 0, newError("TypeError: expected a DURATION, milliseconds or a duration string, got %s", obj.Type())
+}
+
+func dateTimeInvokables(method string, dt *DateTime, args ...Object) Object {
+	name := "DateTime." + method
+	t := dt.Value
+	switch method {
+	case "format":
+		// format(layout) with a named layout (ISO, RFC1123, DateOnly, ...) or a Go reference layout
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: t.Format(TimeLayout(args[0].(*String).Value))}
+
+	case "to_string":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: dt.Inspect()}
+
+	case "year", "month", "day", "hour", "minute", "second", "millisecond", "year_day", "unix", "unix_milli":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		values := map[string]int64{
+			"year":        int64(t.Year()),
+			"month":       int64(t.Month()),
+			"day":         int64(t.Day()),
+			"hour":        int64(t.Hour()),
+			"minute":      int64(t.Minute()),
+			"second":      int64(t.Second()),
+			"millisecond": int64(t.Nanosecond() / int(time.Millisecond)),
+			"year_day":    int64(t.YearDay()),
+			"unix":        t.Unix(),
+			"unix_milli":  t.UnixMilli(),
+		}
+		return &Integer{Value: values[method]}
+
+	case "weekday":
+		// the English day name, e.g "Monday"
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: t.Weekday().String()}
+
+	case "zone":
+		// the abbreviated zone name in effect, e.g "CET"
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		zone, _ := t.Zone()
+		return &String{Value: zone}
+
+	case "in":
+		// in(zone) the same instant in another time zone
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(STRING_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		location, errObj := LoadLocation(args[0].(*String).Value)
+		if errObj != nil {
+			return errObj
+		}
+		return &DateTime{Value: t.In(location)}
+
+	case "utc", "local":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		if method == "utc" {
+			return &DateTime{Value: t.UTC()}
+		}
+		return &DateTime{Value: t.Local()}
+
+	case "add":
+		// add(duration) also accepts milliseconds or a duration string
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		d, errObj := ToDuration(args[0])
+		if errObj != nil {
+			return errObj
+		}
+		return &DateTime{Value: t.Add(d)}
+
+	case "add_date":
+		// add_date(years, months, days) normalizes overflow, e.g Oct 31 + 1 month is Dec 1
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(3),
+			WithTypes(INTEGER_OBJ, INTEGER_OBJ, INTEGER_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &DateTime{Value: t.AddDate(int(args[0].(*Integer).Value), int(args[1].(*Integer).Value), int(args[2].(*Integer).Value))}
+
+	case "since":
+		// since(other) the Duration from other to this instant
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+			WithTypes(DATETIME_OBJ),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Duration{Value: t.Sub(args[0].(*DateTime).Value)}
+
+	case "truncate":
+		// truncate(duration) rounds down to a multiple of duration since the zero time
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		d, errObj := ToDuration(args[0])
+		if errObj != nil {
+			return errObj
+		}
+		return &DateTime{Value: t.Truncate(d)}
+
+	case "start_of_day":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &DateTime{Value: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())}
+	}
+	return nil
+}
+
+func durationInvokables(method string, d *Duration, args ...Object) Object {
+	name := "Duration." + method
+	switch method {
+	case "hours", "minutes", "seconds":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		values := map[string]float64{"hours": d.Value.Hours(), "minutes": d.Value.Minutes(), "seconds": d.Value.Seconds()}
+		return &Float{Value: values[method]}
+
+	case "milliseconds":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: d.Value.Milliseconds()}
+
+	case "nanoseconds":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Integer{Value: d.Value.Nanoseconds()}
+
+	case "abs":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &Duration{Value: d.Value.Abs()}
+
+	case "round", "truncate":
+		// round(unit) and truncate(unit), e.g round("1s")
+		if err := CheckTypings(
+			name, args,
+			ExactArgsLength(1),
+		); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		unit, errObj := ToDuration(args[0])
+		if errObj != nil {
+			return errObj
+		}
+		if method == "round" {
+			return &Duration{Value: d.Value.Round(unit)}
+		}
+		return &Duration{Value: d.Value.Truncate(unit)}
+
+	case "to_string":
+		if err := _noArgsExpected(name, args...); err != nil {
+			return newErrorFromTypings(err.Error())
+		}
+		return &String{Value: d.Inspect()}
+	}
+	return nil
+}
+
+// ParseDateTime parses text with a layout, the zone is used when the text has no offset.
+func ParseDateTime(text, layout string, location *time.Location) (*DateTime, Object) {
+	t, err := time.ParseInLocation(TimeLayout(layout), strings.TrimSpace(text), location)
+	if err != nil {
+		var parseErr *time.ParseError
+		if errors.As(err, &parseErr) && parseErr.Message == "" {
+			return nil, newError("ValueError: cannot parse %q as %s, expected %q at %q", text, layout, parseErr.LayoutElem, parseErr.ValueElem)
+		}
+		return nil, newError("ValueError: cannot parse %q as %s%s", text, layout, strings.TrimPrefix(err.Error(), "parsing time "+strconv.Quote(strings.TrimSpace(text))))
+	}
+	return &DateTime{Value: t}, nil
+}
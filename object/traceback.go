@@ -0,0 +1,97 @@
+package object
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxTracebackFrames bounds the frames an error keeps, the outermost ones are
+// dropped past it and only counted.
+const maxTracebackFrames = 100
+
+// Frame is a call an error propagated out of: Function was called at
+// File:Line:Column, in the module File.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+	Column   int
+	// Repeated counts the identical calls right outside this one, e.g. of a
+	// recursive function, which are kept as this single frame.
+	Repeated int
+}
+
+// WithFrame returns a copy of e with frame added to its traceback, as the
+// call outside of the ones already there.
+func (e *Error) WithFrame(frame Frame) *Error {
+	traceback := make([]Frame, len(e.Traceback), len(e.Traceback)+1)
+	copy(traceback, e.Traceback)
+	omitted := e.Omitted
+	switch last := len(traceback) - 1; {
+	case last >= 0 && sameCall(traceback[last], frame):
+		traceback[last].Repeated++
+	case len(traceback) == maxTracebackFrames:
+		omitted++
+	default:
+		traceback = append(traceback, frame)
+	}
+	return &Error{Message: e.Message, Traceback: traceback, Omitted: omitted, Exit: e.Exit, Code: e.Code}
+}
+
+func sameCall(a, b Frame) bool {
+	return a.Function == b.Function && a.File == b.File && a.Line == b.Line && a.Column == b.Column
+}
+
+// errorPosition matches the "file:line:column: " an error message starts with
+// when the evaluator raised it.
+var errorPosition = regexp.MustCompile(`^(.+?):(\d+):(\d+): `)
+
+// Located reports whether e knows where it was raised: its message starts with
+// a position or it has a traceback.
+func (e *Error) Located() bool {
+	return len(e.Traceback) > 0 || errorPosition.MatchString(e.Message)
+}
+
+// FormatTraceback renders e like Python does, the most recent call last:
+//
+//	Traceback (most recent call last):
+//	  File "main.eso", line 9, in <module>
+//	  File "lib/orders.eso", line 4, in Total
+//	  File "lib/orders.eso", line 12, in price
+//	ValueError: price of 'pen' is not a number
+//
+// Each line is where a function was called, in the function that called it,
+// and the last is where the error was raised. An error without a traceback is
+// its plain message.
+func (e *Error) FormatTraceback() string {
+	if len(e.Traceback) == 0 {
+		return e.Message
+	}
+	var out strings.Builder
+	out.WriteString("Traceback (most recent call last):\n")
+	if e.Omitted > 0 {
+		fmt.Fprintf(&out, "  [%d more calls]\n", e.Omitted)
+	}
+	for i := len(e.Traceback) - 1; i >= 0; i-- {
+		frame := e.Traceback[i]
+		// the code at the call site belongs to the function called by the frame outside of it
+		caller := "<module>"
+		if i+1 < len(e.Traceback) {
+			caller = e.Traceback[i+1].Function
+		}
+		fmt.Fprintf(&out, "  File %q, line %d, in %s\n", frame.File, frame.Line, caller)
+		if frame.Repeated > 0 {
+			fmt.Fprintf(&out, "  [Previous line repeated %d more times]\n", frame.Repeated)
+		}
+	}
+	message := e.Message
+	if match := errorPosition.FindStringSubmatch(message); match != nil {
+		line, _ := strconv.Atoi(match[2])
+		fmt.Fprintf(&out, "  File %q, line %d, in %s\n", match[1], line, e.Traceback[0].Function)
+		message = message[len(match[0]):]
+	}
+	out.WriteString(message)
+	return out.String()
+}
@@ -64,3 +64,19 @@ func WithTypes(types ...ObjectType) CheckFunc {
 		return nil
 	}
 }
+
+// WithTypeSets is WithTypes where an argument may have any type of its set,
+// a nil set takes any type.
+func WithTypeSets(sets ...[]ObjectType) CheckFunc {
+	return func(name string, args []Object) error {
+		for i, types := range sets {
+			if i < len(args) && types != nil && !hasType(types, args[i].Type()) {
+				return fmt.Errorf(
+					"TypeError: %s() expected argument #%d to be `%s` got `%s`",
+					name, (i + 1), joinTypes(types), args[i].Type(),
+				)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"esolang/lang-esolang/mod"
+	"esolang/lang-esolang/utils"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/charmbracelet/log"
+)
+
+// commands are the subcommands, they take the place of a script.
+var commands = map[string]func(args []string) error{
+	"build":  buildCommand,
+	"init":   initCommand,
+	"get":    getCommand,
+	"vendor": vendorCommand,
+}
+
+// loadPackages makes the packages of the esolang.mod governing dir importable.
+func loadPackages(dir string, logger *log.Logger) {
+	manifest := mod.FindManifest(dir)
+	if manifest == "" {
+		return
+	}
+	dirs, missing, err := mod.Resolve(manifest)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+	for name, dir := range dirs {
+		utils.AddPackage(name, dir)
+	}
+	for _, m := range missing {
+		logger.Warn(m)
+	}
+}
+
+// initCommand writes an esolang.mod for a new package in the working directory.
+//
+//	esolang init [package name]
+func initCommand(args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cwd, mod.ManifestFile)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	name := filepath.Base(cwd)
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if err := mod.NewManifest(path, name).Write(); err != nil {
+		return err
+	}
+	fmt.Printf("created %s for package %s\n", mod.ManifestFile, name)
+	return nil
+}
+
+// getCommand adds or changes a dependency when one is given, then fetches every
+// dependency and writes the lockfile.
+//
+//	esolang get [-u] [name git-url-or-path [version]]
+func getCommand(args []string) error {
+	flags := flag.NewFlagSet("get", flag.ContinueOnError)
+	update := flags.Bool("u", false, "resolve versions again instead of using the lockfile")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := currentManifest()
+	if err != nil {
+		return err
+	}
+	switch flags.NArg() {
+	case 0:
+	case 2, 3:
+		if err := m.SetRequire(flags.Arg(0), flags.Arg(1), flags.Arg(2)); err != nil {
+			return err
+		}
+	default:
+		return errors.New("usage: esolang get [-u] [name git-url-or-path [version]]")
+	}
+
+	lock, err := mod.Get(m, mod.GetOptions{Update: *update, Log: os.Stderr})
+	if err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		if err := m.Write(); err != nil {
+			return err
+		}
+	}
+	if err := lock.Write(filepath.Join(m.Dir(), mod.LockFile)); err != nil {
+		return err
+	}
+	printLock(lock)
+	return nil
+}
+
+// vendorCommand copies the locked dependencies into vendor/.
+//
+//	esolang vendor
+func vendorCommand(args []string) error {
+	if len(args) > 0 {
+		return errors.New("usage: esolang vendor")
+	}
+	m, err := currentManifest()
+	if err != nil {
+		return err
+	}
+	lock, err := mod.Get(m, mod.GetOptions{Log: os.Stderr})
+	if err != nil {
+		return err
+	}
+	if err := lock.Write(filepath.Join(m.Dir(), mod.LockFile)); err != nil {
+		return err
+	}
+	if err := mod.Vendor(m, lock); err != nil {
+		return err
+	}
+	fmt.Printf("vendored %d packages into %s\n", len(lock.Entries), filepath.Join(m.Dir(), mod.VendorDir))
+	return nil
+}
+
+func currentManifest() (*mod.Manifest, error) {
+	manifest := mod.FindManifest(".")
+	if manifest == "" {
+		return nil, fmt.Errorf("no %s found, create one with `esolang init`", mod.ManifestFile)
+	}
+	return mod.ReadManifest(manifest)
+}
+
+func printLock(lock *mod.Lock) {
+	names := make([]string, 0, len(lock.Entries))
+	for name := range lock.Entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e := lock.Entries[name]
+		fmt.Printf("%s %s %s\n", e.Name, e.Source, e.Commit)
+	}
+}
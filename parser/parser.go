@@ -4,10 +4,13 @@ Package parser implements the core top-down recursive descent parser i.e a Pratt
 package parser
 
 import (
+	"errors"
 	"esolang/lang-esolang/ast"
 	"esolang/lang-esolang/lexer"
 	"esolang/lang-esolang/token"
 	"fmt"
+	"math/big"
+	"regexp"
 	"strconv"
 )
 
@@ -104,10 +107,14 @@ func New(L *lexer.Lexer) *Parser {
 	p.registerPrefix(token.IF, p.parseIfExpression)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.REGEX, p.parseRegexLiteral)
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(token.WHEN, p.parseWhenLoopExpression)
 	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 	p.registerPrefix(token.IMPORT, p.parseImportExpression)
+	p.registerPrefix(token.SPAWN, p.parseSpawnExpression)
+	p.registerPrefix(token.ASYNC, p.parseAsyncExpression)
+	p.registerPrefix(token.AWAIT, p.parseAwaitExpression)
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
@@ -235,6 +242,10 @@ peekError returns an error message when the next token is not as expected
 */
 func (P *Parser) peekError(t token.TokenType) {
 	msg := fmt.Sprintf("%s: Line %v Column %v - expected next token to be %s got %s", P.currentToken.FileName, P.peekToken.Line, P.peekToken.Column, t, P.peekToken.Type)
+	if t == token.IDENT && P.peekToken.Type == token.LookupIdent(P.peekToken.Literal) {
+		// a keyword such as spawn, which older scripts may still use as a name
+		msg += fmt.Sprintf(", %s is a reserved keyword", P.peekToken.Literal)
+	}
 	P.errors = append(P.errors, msg)
 }
 
@@ -277,6 +288,17 @@ func (P *Parser) parseStatement() ast.Statement {
 		return P.parseLetStatement()
 	case token.RETURN:
 		return P.parseReturnStatement()
+	case token.IMPORT:
+		if P.peekTokenMatches(token.STRING) {
+			return P.parseImportStatement()
+		}
+		return P.parseExpressionStatement()
+	case token.IDENT:
+		// `from` is only special when a module path follows, it stays usable as a name
+		if P.currentToken.Literal == "from" && P.peekTokenMatches(token.STRING) {
+			return P.parseFromImportStatement()
+		}
+		return P.parseExpressionStatement()
 	default:
 		return P.parseExpressionStatement()
 	}
@@ -332,6 +354,70 @@ func (P *Parser) parseLetStatement() *ast.LetStatement {
 	return stmt
 }
 
+// parseImportStatement parses `import "eso/string" as s`
+func (P *Parser) parseImportStatement() ast.Statement {
+	stmt := &ast.ImportStatement{Token: P.currentToken}
+	P.nextToken()
+	stmt.Path = &ast.StringLiteral{Token: P.currentToken, Value: P.currentToken.Literal}
+
+	if !P.expectAs() || !P.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Alias = &ast.Identifier{Token: P.currentToken, Value: P.currentToken.Literal}
+
+	if P.peekTokenMatches(token.SEMICOLON) {
+		P.nextToken()
+	}
+	return stmt
+}
+
+// parseFromImportStatement parses `from "eso/math" import Sqrt, PI as pi`
+func (P *Parser) parseFromImportStatement() ast.Statement {
+	stmt := &ast.ImportStatement{Token: P.currentToken}
+	P.nextToken()
+	stmt.Path = &ast.StringLiteral{Token: P.currentToken, Value: P.currentToken.Literal}
+
+	if !P.expectPeek(token.IMPORT) {
+		return nil
+	}
+	for {
+		if !P.expectPeek(token.IDENT) {
+			return nil
+		}
+		name := &ast.ImportedName{Name: &ast.Identifier{Token: P.currentToken, Value: P.currentToken.Literal}}
+		name.Alias = name.Name
+		if P.peekToken.Type == token.IDENT && P.peekToken.Literal == "as" {
+			P.nextToken()
+			if !P.expectPeek(token.IDENT) {
+				return nil
+			}
+			name.Alias = &ast.Identifier{Token: P.currentToken, Value: P.currentToken.Literal}
+		}
+		stmt.Names = append(stmt.Names, name)
+
+		if !P.peekTokenMatches(token.COMMA) {
+			break
+		}
+		P.nextToken()
+	}
+
+	if P.peekTokenMatches(token.SEMICOLON) {
+		P.nextToken()
+	}
+	return stmt
+}
+
+// expectAs moves past the `as` of an import, it is a keyword only there
+func (P *Parser) expectAs() bool {
+	if P.peekToken.Type == token.IDENT && P.peekToken.Literal == "as" {
+		P.nextToken()
+		return true
+	}
+	msg := fmt.Sprintf("%s: Line %v Column %v - expected next token to be as got %s", P.currentToken.FileName, P.peekToken.Line, P.peekToken.Column, P.peekToken.Literal)
+	P.errors = append(P.errors, msg)
+	return false
+}
+
 // expectPeek checks if the next token is as expected - returns a boolean
 func (P *Parser) expectPeek(t token.TokenType) bool {
 	if P.peekTokenMatches(t) {
@@ -366,6 +452,12 @@ func (P *Parser) parseIntegerLiteral() ast.Expression {
 	// defer untrace(trace("parseIntegerLiteral"))
 	lit := &ast.IntegerLiteral{Token: P.currentToken}
 	value, err := strconv.ParseInt(P.currentToken.Literal, 0, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		// too large for an int64, the literal becomes a BigInt
+		if n, ok := new(big.Int).SetString(P.currentToken.Literal, 0); ok {
+			return &ast.BigIntegerLiteral{Token: P.currentToken, Value: n}
+		}
+	}
 	if err != nil {
 		msg := fmt.Sprintf("%s Line %v Column %v - could not parse %q as integer", P.currentToken.FileName, P.currentToken.Line, P.currentToken.Column, P.currentToken)
 		P.errors = append(P.errors, msg)
@@ -656,6 +748,11 @@ func (P *Parser) parseMethodCallExpression(obj ast.Expression) ast.Expression {
 	method := &ast.ObjectCallExpression{Token: P.currentToken, Object: obj}
 	P.nextToken()
 	name := P.parseIdentifier()
+	if !P.peekTokenMatches(token.LPAREN) {
+		// a property such as resp.status calls the method without arguments
+		method.Call = &ast.CallExpression{Token: P.currentToken, Function: name}
+		return method
+	}
 	P.nextToken()
 	method.Call = P.parseCallExpression(name)
 	return method
@@ -675,6 +772,49 @@ func (P *Parser) parseImportExpression() ast.Expression {
 	return exp
 }
 
+// parseSpawnExpression parses `spawn fn() { ... }` or `spawn work(a, b)`
+func (P *Parser) parseSpawnExpression() ast.Expression {
+	exp := &ast.SpawnExpression{Token: P.currentToken}
+	P.nextToken()
+	exp.Task = P.parseExpression(PREFIX)
+	return exp
+}
+
+// parseAsyncExpression parses `async func name() {}`, `async fn() {}` and `async \`command\``
+func (P *Parser) parseAsyncExpression() ast.Expression {
+	asyncToken := P.currentToken
+	switch P.peekToken.Type {
+	case token.DEF_FN:
+		P.nextToken()
+		if lit, ok := P.parseFunctionDefinition().(*ast.FunctionDefineLiteral); ok {
+			lit.Async = true
+			return lit
+		}
+		return nil
+	case token.FUNCTION:
+		P.nextToken()
+		if lit, ok := P.parseFunctionLiteral().(*ast.FunctionLiteral); ok {
+			lit.Async = true
+			return lit
+		}
+		return nil
+	case token.BACKTICK:
+		P.nextToken()
+		return &ast.SpawnExpression{Token: asyncToken, Task: P.parseBacktickLiteral()}
+	}
+	msg := fmt.Sprintf("%s: Line %v Column %v - expected func, fn or a command after async got %s", P.currentToken.FileName, P.peekToken.Line, P.peekToken.Column, P.peekToken.Type)
+	P.errors = append(P.errors, msg)
+	return nil
+}
+
+// parseAwaitExpression parses `await task`
+func (P *Parser) parseAwaitExpression() ast.Expression {
+	exp := &ast.AwaitExpression{Token: P.currentToken}
+	P.nextToken()
+	exp.Value = P.parseExpression(PREFIX)
+	return exp
+}
+
 func (P *Parser) parseSelectorExpression(exp ast.Expression) ast.Expression {
 	P.expectPeek(token.IDENT)
 	index := &ast.StringLiteral{Token: P.currentToken, Value: P.currentToken.Literal}
@@ -819,3 +959,17 @@ func (p *Parser) parsePostfixExpression() ast.Expression {
 func (P *Parser) parseBacktickLiteral() ast.Expression {
 	return &ast.BacktickLiteral{Token: P.currentToken, Value: P.currentToken.Literal}
 }
+
+// parseRegexLiteral parses a regular expression literal such as re"[0-9]+".
+// The pattern is compiled here so that syntax errors are reported with their location.
+func (P *Parser) parseRegexLiteral() ast.Expression {
+	lit := &ast.RegexLiteral{Token: P.currentToken, Value: P.currentToken.Literal}
+	re, err := regexp.Compile(lit.Value)
+	if err != nil {
+		msg := fmt.Sprintf("%s Line %v Column %v - invalid regular expression: %s", P.currentToken.FileName, P.currentToken.Line, P.currentToken.Column, err)
+		P.errors = append(P.errors, msg)
+		return nil
+	}
+	lit.Regexp = re
+	return lit
+}
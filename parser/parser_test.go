@@ -48,6 +48,8 @@ func TestLetStatements(t *testing.T) {
 func TestObjectMethodCall(t *testing.T) {
 	input := []string{
 		"\"string\".len()",
+		"response.status == 200",
+		"response.headers[\"content-type\"]",
 	}
 
 	for _, txt := range input {
@@ -484,6 +486,18 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a * b[2], b[1], 2 * [1, 2][1])",
 			"add((a * (b[2])),(b[1]),(2 * ([1, 2][1])))",
 		},
+		{
+			"spawn work(a, b) + 1",
+			"((spawn work(a,b)) + 1)",
+		},
+		{
+			"await fetch(a) + await fetch(b)",
+			"((await fetch(a)) + (await fetch(b)))",
+		},
+		{
+			"let f = async fn(a) { a }",
+			"let f = async fn(a)a;",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1050,6 +1064,55 @@ func TestParsingImportStatement(t *testing.T) {
 	}
 }
 
+func TestParsingImportForms(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{`import "eso/string" as s`, `import "eso/string" as s;`},
+		{`from "eso/math" import Sqrt, PI as pi;`, `from "eso/math" import Sqrt, PI as pi;`},
+		{`let from = 1; from + 1`, `let from = 1;(from + 1)`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(FILE, tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.output {
+			t.Errorf("wrong import output. want=%s, got=%s", tt.output, program.String())
+		}
+	}
+
+	for _, input := range []string{`import "eso/string"`, `from "eso/math" import`, `from "eso/math" import Sqrt as`} {
+		p := New(lexer.New(FILE, input))
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected a parse error for %q", input)
+		}
+	}
+}
+
+func TestReservedKeywordsAsIdentifiers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let spawn = 1`, "<test>: Line 1 Column 11 - expected next token to be IDENT got SPAWN, spawn is a reserved keyword"},
+		{`let async = 2`, "<test>: Line 1 Column 11 - expected next token to be IDENT got ASYNC, async is a reserved keyword"},
+		{`let await = 3`, "<test>: Line 1 Column 11 - expected next token to be IDENT got AWAIT, await is a reserved keyword"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(FILE, tt.input))
+		p.ParseProgram()
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("wrong error for %q. want=%q, got=%q", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
+
 func TestBindExpressions(t *testing.T) {
 
 	tests := []struct {
@@ -0,0 +1,240 @@
+/*
+Package esolang hosts the interpreter in a Go program.
+
+	in := esolang.NewInterpreter(esolang.Options{Stdout: &out, FS: os.DirFS("rules")})
+	if _, err := in.Eval(ctx, `from "discounts" import Discount`); err != nil {
+		...
+	}
+	v, err := in.Call("Discount", map[string]any{"total": 120})
+	percent, _ := v.Int()
+
+Values cross the boundary with ToObject and Value.Interface, see their docs
+for how each type is converted.
+*/
+package esolang
+
+import (
+	"context"
+	"esolang/lang-esolang/builtins"
+	"esolang/lang-esolang/evaluator"
+	"esolang/lang-esolang/lexer"
+	"esolang/lang-esolang/object"
+	"esolang/lang-esolang/parser"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Options configures an Interpreter, the zero value prints nowhere and uses
+// the host filesystem.
+type Options struct {
+	// Stdout receives print and println, nil discards it.
+	Stdout io.Writer
+	// Stderr receives the errors raised by the handlers of a script's server, nil discards it.
+	Stderr io.Writer
+	// FS is the filesystem scripts read files and import modules from. It is
+	// read-only to them, writes fail with an IOError. Nil is the host filesystem.
+	FS fs.FS
+	// Args is the ARGS array of the scripts, process::Args() returns them after Name.
+	Args []string
+	// Name is the file name errors report for evaluated source, "<eval>" when empty.
+	Name string
+	// Limits bounds each evaluation and call, an evaluation over a limit
+	// fails with a RuntimeError.
+	Limits object.Limits
+	// Permissions, when set, sandboxes the scripts, see object.Permissions.
+	// FS is read-only whatever it allows. Nil leaves the scripts unrestricted:
+	// they can run commands and change the environment variables and working
+	// directory of the host process, set it to evaluate untrusted scripts.
+	Permissions *object.Permissions
+}
+
+// Interpreter evaluates source in a global scope kept between calls. It is safe
+// for concurrent use, evaluations and calls run one at a time. A Go function
+// called by a script must not call back into its Interpreter.
+type Interpreter struct {
+	mu      sync.Mutex
+	env     *object.Environment
+	runtime *object.Runtime
+	name    string
+}
+
+func NewInterpreter(opts Options) *Interpreter {
+	rt := object.NewRuntime(opts.Stdout, opts.Stderr, opts.FS)
+	rt.Limits = opts.Limits
+	rt.Permissions = opts.Permissions
+	env := object.NewEnvironment()
+	env.SetRuntime(rt)
+	args := make([]object.Object, len(opts.Args))
+	for i, arg := range opts.Args {
+		args[i] = &object.String{Value: arg}
+	}
+	env.Set("ARGS", &object.Array{Elements: args})
+
+	name := opts.Name
+	if name == "" {
+		name = "<eval>"
+	}
+	rt.Args = append([]string{name}, opts.Args...)
+	return &Interpreter{env: env, runtime: rt, name: name}
+}
+
+// SyntaxError is returned when source does not parse, nothing of it was evaluated.
+type SyntaxError struct {
+	Errors []string
+}
+
+func (e *SyntaxError) Error() string {
+	return strings.Join(e.Errors, "\n")
+}
+
+// RuntimeError is an error raised by a script, e.g.
+// "<eval>:1:5: TypeError: unsupported operand type(s) for +: 'STRING' and 'INTEGER'".
+type RuntimeError struct {
+	Message string
+}
+
+func (e *RuntimeError) Error() string {
+	return e.Message
+}
+
+// ExitError is returned when a script calls process::Exit, the evaluation stops
+// there and the host process keeps running.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("esolang: script exited with status %d", e.Code)
+}
+
+// Eval evaluates src and returns the value of its last statement, Null when it
+// is a statement without a value. Top level definitions stay in the globals.
+// When ctx is done before the evaluation finishes Eval returns ctx.Err(), the
+// evaluation stops at its next statement, loop iteration or call.
+func (in *Interpreter) Eval(ctx context.Context, src string) (Value, error) {
+	if err := ctx.Err(); err != nil {
+		return Value{}, err
+	}
+	p := parser.New(lexer.New(in.name, src))
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return Value{}, &SyntaxError{Errors: p.Errors()}
+	}
+	return in.run(ctx, func() object.Object {
+		return evaluator.Eval(program, in.env)
+	})
+}
+
+// run runs eval as the interpreter's current evaluation once the previous one
+// is over, it returns early with ctx.Err() when ctx is done.
+func (in *Interpreter) run(ctx context.Context, eval func() object.Object) (Value, error) {
+	done := make(chan object.Object, 1)
+	go func() {
+		in.mu.Lock()
+		defer in.mu.Unlock()
+		if ctx.Err() != nil {
+			done <- nil
+			return
+		}
+		in.runtime.Start(ctx)
+		done <- eval()
+	}()
+	select {
+	case <-ctx.Done():
+		return Value{}, ctx.Err()
+	case evaluated := <-done:
+		return result(evaluated)
+	}
+}
+
+// SetGlobal converts value with ToObject and binds it to name in the globals.
+// A Go function is wrapped by object.NewGoFunction and called name in errors.
+func (in *Interpreter) SetGlobal(name string, value any) error {
+	var obj object.Object
+	var err error
+	switch value.(type) {
+	case object.BuiltinFunction, func(...object.Object) object.Object:
+		obj, err = ToObject(value)
+	default:
+		if reflect.ValueOf(value).Kind() == reflect.Func {
+			obj, err = object.NewGoFunction(name, value)
+		} else {
+			obj, err = ToObject(value)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("esolang: global %s: %w", name, err)
+	}
+	in.env.Set(name, obj)
+	return nil
+}
+
+// Global returns the value bound to name in the globals.
+func (in *Interpreter) Global(name string) (Value, bool) {
+	obj, ok := in.env.Get(name)
+	if !ok {
+		return Value{}, false
+	}
+	return Value{obj}, true
+}
+
+// Call calls the function bound to fnName in the globals, or the builtin of
+// that name, with args converted by ToObject.
+func (in *Interpreter) Call(fnName string, args ...any) (Value, error) {
+	return in.CallContext(context.Background(), fnName, args...)
+}
+
+// CallContext is Call stopping like Eval when ctx is done.
+func (in *Interpreter) CallContext(ctx context.Context, fnName string, args ...any) (Value, error) {
+	fn, ok := in.env.Get(fnName)
+	if !ok {
+		builtin, found := builtins.Builtins[fnName]
+		if !found {
+			return Value{}, fmt.Errorf("esolang: no function named %s", fnName)
+		}
+		fn = builtin
+	}
+	switch fn.(type) {
+	case *object.Function, *object.Builtin:
+	default:
+		return Value{}, fmt.Errorf("esolang: %s is a %s, not a function", fnName, fn.Type())
+	}
+	if f, ok := fn.(*object.Function); ok && len(f.Parameters) != len(args) {
+		return Value{}, fmt.Errorf("esolang: %s takes %d arguments, got %d", fnName, len(f.Parameters), len(args))
+	}
+
+	objects := make([]object.Object, len(args))
+	for i, arg := range args {
+		obj, err := ToObject(arg)
+		if err != nil {
+			return Value{}, fmt.Errorf("esolang: argument %d of %s: %w", i+1, fnName, err)
+		}
+		objects[i] = obj
+	}
+	return in.run(ctx, func() object.Object {
+		return evaluator.Call(in.runtime, fn, objects...)
+	})
+}
+
+// result turns what the evaluator returned into a Value or a RuntimeError.
+func result(obj object.Object) (Value, error) {
+	switch obj := obj.(type) {
+	case nil:
+		return Value{}, nil
+	case *object.Error:
+		if obj.Exit {
+			return Value{}, &ExitError{Code: obj.Code}
+		}
+		return Value{}, &RuntimeError{Message: obj.Message}
+	case *object.String:
+		// print and println return what they printed for the REPL, it is not a value
+		if obj.Printed {
+			return Value{}, nil
+		}
+	}
+	return Value{obj}, nil
+}
@@ -0,0 +1,349 @@
+package esolang
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"esolang/lang-esolang/object"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestEvalAndCall(t *testing.T) {
+	var out bytes.Buffer
+	in := NewInterpreter(Options{Stdout: &out, Args: []string{"-v"}})
+
+	v, err := in.Eval(context.Background(), `
+let rate = 2
+func Total(items) {
+	let sum = (items[0]["price"] + items[1]["price"]) * rate
+	println("total", sum)
+	sum
+}
+ARGS`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.Interface(), []any{"-v"}) {
+		t.Errorf("wrong ARGS: %v", v)
+	}
+	v, err = in.Eval(context.Background(), `import("eso/process")::Args()`)
+	if err != nil || !reflect.DeepEqual(v.Interface(), []any{"<eval>", "-v"}) {
+		t.Errorf("wrong process::Args(): %v %v", v, err)
+	}
+
+	if err := in.SetGlobal("rate", 3); err != nil {
+		t.Fatal(err)
+	}
+	items := []map[string]any{{"price": 2}, {"price": uint8(5)}}
+	v, err = in.Call("Total", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := v.Int(); !ok || n != 21 {
+		t.Errorf("wrong total: %v", v)
+	}
+	if out.String() != "total\n21\n" {
+		t.Errorf("wrong output: %q", out.String())
+	}
+
+	// a script ending with println has no value
+	if v, err := in.Eval(context.Background(), `println("x")`); err != nil || !v.IsNull() {
+		t.Errorf("expected null, got %v %v", v, err)
+	}
+	if v, err := in.Eval(context.Background(), `"hidden flag=noshow"`); err != nil || v.String() != "hidden flag=noshow" {
+		t.Errorf("a string is a value whatever it ends with, got %v %v", v, err)
+	}
+
+	if _, err := in.Call("rate"); err == nil || err.Error() != "esolang: rate is a INTEGER, not a function" {
+		t.Errorf("wrong error calling a value: %v", err)
+	}
+	if _, err := in.Call("Total"); err == nil || err.Error() != "esolang: Total takes 1 arguments, got 0" {
+		t.Errorf("wrong error for a missing argument: %v", err)
+	}
+	if _, err := in.Call("Missing"); err == nil || err.Error() != "esolang: no function named Missing" {
+		t.Errorf("wrong error for an unknown function: %v", err)
+	}
+	if v, err := in.Call("type_of", "four"); err != nil || v.String() != "STRING" {
+		t.Errorf("wrong result calling a builtin: %v %v", v, err)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	in := NewInterpreter(Options{Name: "rule.eso"})
+
+	_, err := in.Eval(context.Background(), "let = 1")
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || !strings.Contains(syntaxErr.Errors[0], "expected next token to be IDENT") {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+
+	_, err = in.Eval(context.Background(), "\nlet x = 1 / 0")
+	var runtimeErr *RuntimeError
+	if !errors.As(err, &runtimeErr) || !strings.HasPrefix(runtimeErr.Message, "rule.eso:2:") {
+		t.Errorf("expected a runtime error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := in.Eval(ctx, "1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if err := in.SetGlobal("bad", struct{}{}); err == nil || err.Error() != "esolang: global bad: unsupported type struct {}" {
+		t.Errorf("wrong error for an unsupported value: %v", err)
+	}
+}
+
+func TestFilesystem(t *testing.T) {
+	fsys := fstest.MapFS{
+		"rules/discount.eso": {Data: []byte(`let helpers = import("./helpers"); func Discount(total) { helpers::Percent(total) }`)},
+		"rules/helpers.eso":  {Data: []byte(`func Percent(total) { if (total > 100) { 10 } else { 0 } }`)},
+		"data/limits.txt":    {Data: []byte("100")},
+	}
+	in := NewInterpreter(Options{FS: fsys})
+
+	v, err := in.Eval(context.Background(), `from "rules/discount" import Discount
+[Discount(120), ReadFile("./data/limits.txt")]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.Interface(), []any{int64(10), "100"}) {
+		t.Errorf("wrong result: %v", v)
+	}
+
+	_, err = in.Eval(context.Background(), `WriteFile("data/limits.txt", "0")`)
+	if err == nil || !strings.Contains(err.Error(), "IOError: write data/limits.txt: read-only file system") {
+		t.Errorf("expected a read-only error, got %v", err)
+	}
+	_, err = in.Eval(context.Background(), `import("/etc/passwd")`)
+	if err == nil || !strings.Contains(err.Error(), "no module named '/etc/passwd'") {
+		t.Errorf("expected a missing module, got %v", err)
+	}
+	if string(fsys["data/limits.txt"].Data) != "100" {
+		t.Errorf("the filesystem was written to")
+	}
+}
+
+func TestValueConversion(t *testing.T) {
+	when := time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	in := NewInterpreter(Options{})
+	globals := map[string]any{
+		"values": []any{nil, true, 1.5, "s", when, time.Second, huge, uint64(1 << 63)},
+		"nested": map[string][]int{"a": {1, 2}},
+		"keys":   map[int]string{1: "one"},
+	}
+	for name, value := range globals {
+		if err := in.SetGlobal(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v, err := in.Eval(context.Background(), `[values, nested, keys, Set()]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []any{
+		[]any{nil, true, 1.5, "s", when, time.Second, huge, new(big.Int).SetUint64(1 << 63)},
+		map[string]any{"a": []any{int64(1), int64(2)}},
+		map[string]any{"1": "one"},
+		[]any{},
+	}
+	if !reflect.DeepEqual(v.Interface(), expected) {
+		t.Errorf("wrong conversion. expected=%v, got=%v", expected, v.Interface())
+	}
+
+	fn, _ := in.Eval(context.Background(), `fn(x) { x }`)
+	if fn.Type() != "FUNCTION" || fn.Interface() != fn.Object() {
+		t.Errorf("a function should be returned as its object, got %T", fn.Interface())
+	}
+}
+
+func TestGoFunctions(t *testing.T) {
+	in := NewInterpreter(Options{})
+	err := in.SetGlobal("lookup", func(id int, fields ...string) (map[string]any, error) {
+		if id != 7 {
+			return nil, fmt.Errorf("KeyError: no user %d", id)
+		}
+		user := map[string]any{"id": id}
+		for _, f := range fields {
+			user[f] = strings.ToUpper(f)
+		}
+		return user, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := in.Eval(context.Background(), `lookup(7, "name")["name"]`)
+	if err != nil || v.String() != "NAME" {
+		t.Errorf("wrong result: %v %v", v, err)
+	}
+	_, err = in.Eval(context.Background(), `lookup(8)`)
+	if err == nil || err.Error() != "KeyError: no user 8" {
+		t.Errorf("wrong error: %v", err)
+	}
+	_, err = in.Eval(context.Background(), `lookup("7")`)
+	if err == nil || err.Error() != "TypeError: lookup() expected argument #1 to be `INTEGER` got `STRING`" {
+		t.Errorf("wrong type error: %v", err)
+	}
+	if err := in.SetGlobal("bad", func(c chan int) {}); err == nil || err.Error() != "esolang: global bad: parameter #1: unsupported type chan int" {
+		t.Errorf("wrong error for an unsupported function: %v", err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		limits   object.Limits
+		src      string
+		expected string
+	}{
+		{object.Limits{MaxSteps: 1000}, `when (true) {}`, "LimitError: step limit of 1000 exceeded"},
+		{object.Limits{}, `func Down(n) { Down(n + 1) }; Down(0)`, "RecursionError: maximum call depth of 50000 exceeded"},
+		{object.Limits{MaxCallDepth: 10}, `func Down(n) { Down(n + 1) }; Down(0)`, "<eval>:1:15: RecursionError: maximum call depth of 10 exceeded"},
+		{object.Limits{MaxCollectionSize: 100}, `"ab" * 51`, "<eval>:1:7: MemoryError: size 102 exceeds the collection size limit of 100"},
+		{object.Limits{MaxCollectionSize: 100}, `let s = "ab"; s *= 9223372036854775807`, "MemoryError: size 9223372036854775807 exceeds the collection size limit of 100"},
+		{object.Limits{MaxCollectionSize: 3}, `[1, 2] + [3]`, ""},
+	}
+	for _, tt := range tests {
+		in := NewInterpreter(Options{Limits: tt.limits})
+		_, err := in.Eval(context.Background(), tt.src)
+		if tt.expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected %q, got %v", tt.src, tt.expected, err)
+		}
+	}
+}
+
+func TestEvalContext(t *testing.T) {
+	in := NewInterpreter(Options{})
+	blocking := []string{
+		`when (true) {}`,
+		`time_sleep(60000)`,
+		`Channel().recv()`,
+		`Channel().send(1)`,
+		`Channel().each(fn(v) { v })`,
+		`select([Channel()])`,
+		`await spawn fn() { Channel().recv() }`,
+		`(spawn fn() { Channel().recv() }).await(60000)`,
+		`async_all([spawn fn() { Channel().recv() }])`,
+		`async_race([spawn fn() { Channel().recv() }])`,
+		`process_spawn(["sleep", "60"])`,
+		"`sleep 60`",
+	}
+	for _, src := range blocking {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := in.Eval(ctx, src)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected context.DeadlineExceeded, got %v", src, err)
+		}
+	}
+
+	// the stopped evaluations give the interpreter back
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if v, err := in.Eval(ctx, `func Loop() { let i = 0; when (true) { i += 1; if (i == 10) { return i } } }; Loop()`); err != nil || v.String() != "10" {
+		t.Errorf("expected 10, got %v %v", v, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the stopped evaluations held the interpreter for %s", elapsed)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := in.CallContext(ctx, "Loop"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestExit(t *testing.T) {
+	in := NewInterpreter(Options{})
+	_, err := in.Eval(context.Background(), `func Stop() { import("eso/process")::Exit(3); "not reached" }; Stop()`)
+	var exit *ExitError
+	if !errors.As(err, &exit) || exit.Code != 3 {
+		t.Fatalf("expected an ExitError with code 3, got %v", err)
+	}
+	if v, err := in.Eval(context.Background(), `1 + 1`); err != nil || v.String() != "2" {
+		t.Errorf("the interpreter should still run after an exit, got %v %v", v, err)
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"data/in.txt": "in", "secret.txt": "secret", "secret.eso": "let Secret = 42"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(dir, "data", "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	in := NewInterpreter(Options{Permissions: &object.Permissions{
+		Read:  []string{filepath.Join(dir, "data")},
+		Write: []string{filepath.Join(dir, "out")},
+	}})
+	if err := in.SetGlobal("dir", dir); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`ReadFile(dir + "/data/in.txt")`, ""},
+		{`ReadFile(dir + "/data/../secret.txt")`, "PermissionError: read access to '" + dir + "/data/../secret.txt' denied"},
+		{`ReadFile(dir + "/data/link.txt")`, "PermissionError: read access to '" + dir + "/data/link.txt' denied"},
+		{`fs_exists(dir + "/secret.txt")`, "PermissionError"},
+		{`re".+".replace_fn(dir + "/secret.txt", ReadFile)`, "PermissionError: read access"},
+		{`WriteFile(dir + "/data/in.txt", "x")`, "PermissionError: write access to '" + dir + "/data/in.txt' denied"},
+		{`fs_mkdir_all(dir + "/out/new")`, ""},
+		{`fs_copy(dir + "/secret.txt", dir + "/out/secret.txt")`, "PermissionError: read access"},
+		{"`ls`", "<eval>:1:2: PermissionError: run access denied"},
+		{`process_getenv("HOME")`, "PermissionError: env access denied"},
+		{`process_setenv("ESO_TEST", "x")`, "PermissionError: env access denied"},
+		{`process_cwd()`, "PermissionError: env access denied"},
+		{`process_pid()`, "PermissionError: env access denied"},
+		{`process_chdir("/")`, "PermissionError: run access denied"},
+		{`process_exit(1)`, "PermissionError: run access denied"},
+		{`http_get("http://localhost")`, "PermissionError: net access denied"},
+		{`Http("GET", "http://localhost")`, "PermissionError: net access denied"},
+		{`server_new()`, "PermissionError: net access denied"},
+		{`import(dir + "/secret")::Secret`, "PermissionError: read access to '" + dir + "/secret.eso' denied"},
+		{`import("eso/string")`, ""},
+	}
+	for _, tt := range tests {
+		_, err := in.Eval(context.Background(), tt.src)
+		if tt.expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.src, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected %q, got %v", tt.src, tt.expected, err)
+		}
+	}
+
+	v, err := in.Eval(context.Background(), `fs_glob(dir + "/*/*.txt")`)
+	if err != nil || !reflect.DeepEqual(v.Interface(), []any{dir + "/data/in.txt"}) {
+		t.Errorf("glob should leave out unreadable paths, got %v %v", v, err)
+	}
+}
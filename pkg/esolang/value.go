@@ -0,0 +1,83 @@
+package esolang
+
+import (
+	"esolang/lang-esolang/object"
+)
+
+// Value is an esolang value handed to Go, the zero Value is null.
+type Value struct {
+	obj object.Object
+}
+
+// Object returns the interpreter's object for v, for types Interface does not convert.
+func (v Value) Object() object.Object {
+	if v.obj == nil {
+		return &object.Null{}
+	}
+	return v.obj
+}
+
+// Type is the esolang type of v, e.g. "INTEGER".
+func (v Value) Type() string {
+	return string(v.Object().Type())
+}
+
+func (v Value) IsNull() bool {
+	_, null := v.Object().(*object.Null)
+	return null
+}
+
+// String is how esolang prints v.
+func (v Value) String() string {
+	return v.Object().Inspect()
+}
+
+func (v Value) Int() (int64, bool) {
+	i, ok := v.obj.(*object.Integer)
+	if !ok {
+		return 0, false
+	}
+	return i.Value, true
+}
+
+// Float returns v as a float64, an integer is converted.
+func (v Value) Float() (float64, bool) {
+	switch n := v.obj.(type) {
+	case *object.Float:
+		return n.Value, true
+	case *object.Integer:
+		return float64(n.Value), true
+	}
+	return 0, false
+}
+
+func (v Value) Bool() (bool, bool) {
+	b, ok := v.obj.(*object.Boolean)
+	if !ok {
+		return false, false
+	}
+	return b.Value, true
+}
+
+// Text returns the contents of a string value.
+func (v Value) Text() (string, bool) {
+	s, ok := v.obj.(*object.String)
+	if !ok {
+		return "", false
+	}
+	return s.Value, true
+}
+
+// Interface converts v to a Go value with object.ToGo.
+func (v Value) Interface() any {
+	return object.ToGo(v.Object())
+}
+
+// ToObject converts a Go value to an esolang value with object.FromGo, a
+// Value is used as is.
+func ToObject(value any) (object.Object, error) {
+	if v, ok := value.(Value); ok {
+		return v.Object(), nil
+	}
+	return object.FromGo(value)
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"esolang/lang-esolang/repl"
 	"html/template"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -49,6 +51,9 @@ func newEsolangData() EsolangData {
 	}
 }
 
+// evaluationTimeout is how long a program submitted to the playground may run.
+const evaluationTimeout = 5 * time.Second
+
 func main() {
 	e := echo.New()
 	e.Renderer = NewTemplates()
@@ -62,7 +67,9 @@ func main() {
 	e.POST("/playground", func(c echo.Context) error {
 		//  curl -X POST -F 'sourceCode=println("hi mom")' http://localhost:8080/playground
 		sc := c.FormValue("sourceCode")
-		playGroundRes := repl.EvlauateFromPlayground(sc)
+		ctx, cancel := context.WithTimeout(c.Request().Context(), evaluationTimeout)
+		defer cancel()
+		playGroundRes := repl.EvlauateFromPlayground(ctx, sc)
 		data.Esos = nil
 		data.Esos = append(data.Esos, newEsolangConstruct(sc, playGroundRes))
 		return c.Render(http.StatusOK, "evaluatedView", data)
@@ -5,6 +5,7 @@ package repl
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"esolang/lang-esolang/evaluator"
 	"esolang/lang-esolang/lexer"
@@ -28,8 +29,14 @@ var (
 	REPL_VERSION          = "0.0.1" // todo: get esolang version on the machine
 )
 
-func Execute(sourceName, input string) {
+// Execute runs a script, the arguments following it on the command line are available as ARGS.
+func Execute(sourceName, input string, args []string) {
 	environmnet := object.NewEnvironment()
+	scriptArgs := make([]object.Object, len(args))
+	for i, arg := range args {
+		scriptArgs[i] = &object.String{Value: arg}
+	}
+	environmnet.Set("ARGS", &object.Array{Elements: scriptArgs})
 	logger := generateLogger()
 	evaluteInput(sourceName, input, logger, environmnet)
 }
@@ -98,11 +105,13 @@ func evaluteInput(sourceName, input string, log *log.Logger, environmnet *object
 	if evaluated != nil {
 		output := evaluated.Inspect()
 
-		if len(output) > 5 && output[:5] == "ERROR" {
-			log.Error(output[6:])
+		if err, ok := evaluated.(*object.Error); ok {
+			if err.Exit {
+				os.Exit(err.Code)
+			}
+			log.Error(err.FormatTraceback())
 		} else {
-			var DONT_PRINT = "flag=noshow"
-			if !strings.HasSuffix(output, DONT_PRINT) {
+			if printed, ok := evaluated.(*object.String); !ok || !printed.Printed {
 				if REPL_MODE {
 					cyan := "\033[36m" //Cyan color
 					reset := "\033[0m" // Reset color
@@ -116,7 +125,21 @@ func evaluteInput(sourceName, input string, log *log.Logger, environmnet *object
 		}
 	}
 }
-func EvlauateFromPlayground(input string) string {
+
+// PlaygroundLimits bounds the programs submitted to the playground.
+var PlaygroundLimits = object.Limits{
+	MaxSteps:          10_000_000,
+	MaxCallDepth:      10_000,
+	MaxCollectionSize: 1 << 24,
+}
+
+// PlaygroundPermissions is what programs submitted to the playground may do
+// outside the interpreter: nothing.
+var PlaygroundPermissions = &object.Permissions{}
+
+// EvlauateFromPlayground evaluates input with PlaygroundLimits and
+// PlaygroundPermissions, the evaluation stops with an error once ctx is done.
+func EvlauateFromPlayground(ctx context.Context, input string) string {
 	initialLexer := lexer.New("plaground.eso", input)
 	initialParser := parser.New(initialLexer)
 	environmnet := object.NewEnvironment()
@@ -126,14 +149,14 @@ func EvlauateFromPlayground(input string) string {
 		return initialParser.Errors()[0]
 	}
 
+	rt := object.NewRuntime(os.Stdout, os.Stderr, nil)
+	rt.Limits = PlaygroundLimits
+	rt.Permissions = PlaygroundPermissions
+	rt.Start(ctx)
+	environmnet.SetRuntime(rt)
 	evaluated := evaluator.Eval(program, environmnet)
 	if evaluated != nil {
-		output := evaluated.Inspect()
-		var DONT_PRINT = "flag=noshow"
-		if strings.HasSuffix(output, DONT_PRINT) {
-			output = output[:len(output)-len(DONT_PRINT)]
-		}
-		return output
+		return evaluated.Inspect()
 	} else {
 		return "ERROR: No output found"
 	}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"esolang/lang-esolang/object"
+	"flag"
+	"strings"
+)
+
+// pathList is the value of -allow-read and -allow-write: comma separated paths,
+// the flag can be repeated and allows every path when given without a value.
+type pathList []string
+
+func (l *pathList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *pathList) Set(value string) error {
+	if value == "true" {
+		*l = append(*l, object.AllPaths)
+		return nil
+	}
+	*l = append(*l, strings.Split(value, ",")...)
+	return nil
+}
+
+func (l *pathList) IsBoolFlag() bool {
+	return true
+}
+
+// permissionFlags defines the flags that sandbox scripts. The returned function
+// gives their permissions once the flags are parsed, nil when none was given.
+func permissionFlags() func() *object.Permissions {
+	sandbox := flag.Bool("sandbox", false, "Deny scripts files, the network, commands and environment variables, unless allowed with an -allow flag")
+	var read, write pathList
+	flag.Var(&read, "allow-read", "Sandbox scripts and allow reading these comma separated `paths`, every path without a value")
+	flag.Var(&write, "allow-write", "Sandbox scripts and allow writing these comma separated `paths`, every path without a value")
+	allowNet := flag.Bool("allow-net", false, "Sandbox scripts and allow HTTP requests and servers")
+	allowRun := flag.Bool("allow-run", false, "Sandbox scripts and allow running commands")
+	allowEnv := flag.Bool("allow-env", false, "Sandbox scripts and allow environment variables")
+
+	return func() *object.Permissions {
+		sandboxed := *sandbox
+		flag.Visit(func(f *flag.Flag) {
+			if strings.HasPrefix(f.Name, "allow-") {
+				sandboxed = true
+			}
+		})
+		if !sandboxed {
+			return nil
+		}
+		return &object.Permissions{Read: read, Write: write, Net: *allowNet, Run: *allowRun, Env: *allowEnv}
+	}
+}
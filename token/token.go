@@ -56,6 +56,7 @@ const (
     STRING_EQ   = "IS"
     STRING_NOT_EQ = "IS_NOT"
 	STRING      = "STRING"
+	REGEX       = "REGEX"
 	LBRACKET    = "["
 	RBRACKET    = "]"
 	COLON       = ":"
@@ -69,6 +70,9 @@ const (
 	STRING_AND  = "AND"
 	PERIOD      = "."
 	IMPORT      = "IMPORT"
+	SPAWN       = "SPAWN"
+	ASYNC       = "ASYNC"
+	AWAIT       = "AWAIT"
 )
 
 // Keywords are reserved words
@@ -88,6 +92,9 @@ var keywords = map[string]TokenType{
 	"when":   WHEN,
 	"import": IMPORT,
 	"func":   DEF_FN,
+	"spawn":  SPAWN,
+	"async":  ASYNC,
+	"await":  AWAIT,
 }
 
 // LookupIdent checks if the identifier is a keyword
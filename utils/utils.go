@@ -10,6 +10,11 @@ import (
 
 var SearchPaths []string
 
+// Packages maps the name of a package from esolang.mod, the project's own
+// and its dependencies, to its directory. import("colors/palette") loads
+// palette.eso from the colors package, import("colors") loads colors.eso.
+var Packages = map[string]string{}
+
 func init() {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -36,6 +41,29 @@ func AddPath(path string) error {
 	return nil
 }
 
+// Bundled holds the modules of a program built with `esolang build`, keyed by
+// the path they had when it was built, they are used instead of the disk.
+var Bundled map[string]string
+
+// BundledNames maps each non relative import of a bundled program to its key in Bundled.
+var BundledNames map[string]string
+
+// moduleExists reports whether a module file is bundled or on disk.
+func moduleExists(path string) bool {
+	if _, ok := Bundled[path]; ok {
+		return true
+	}
+	return Exists(path)
+}
+
+// ReadModule returns the source of a module file, bundled or on disk.
+func ReadModule(path string) ([]byte, error) {
+	if code, ok := Bundled[path]; ok {
+		return []byte(code), nil
+	}
+	return os.ReadFile(path)
+}
+
 func Exists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -45,7 +73,68 @@ func IsBuiltinModule(name string) bool {
 	return map[bool]bool{true: true, false: false}[strings.HasPrefix(name, "eso/") && strings.Count(name, "/") == 1]
 }
 
+// IsRelativeModule reports whether name is resolved from the importing file, e.g "./lib/util".
+func IsRelativeModule(name string) bool {
+	return strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../")
+}
+
+// ResolveModule returns the file for name, relative names are looked up in dir,
+// the importing file's directory, absolute ones are used as is and others are
+// looked up in Packages and SearchPaths. It returns "" when there is no such module.
+func ResolveModule(name, dir string) string {
+	filename := name
+	switch {
+	case IsRelativeModule(name):
+		filename = filepath.Join(dir, name)
+	case !filepath.IsAbs(name):
+		return FindModule(name)
+	}
+	if !strings.HasSuffix(filename, ".eso") {
+		filename += ".eso"
+	}
+	if !moduleExists(filename) {
+		return ""
+	}
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	return filename
+}
+
+// AddPackage makes the modules of the package in dir importable under name.
+func AddPackage(name, dir string) {
+	Packages[name] = dir
+}
+
+// findPackageModule resolves name against Packages, the longest matching package name wins.
+func findPackageModule(name string) string {
+	best := ""
+	for pkg := range Packages {
+		if (name == pkg || strings.HasPrefix(name, pkg+"/")) && len(pkg) > len(best) {
+			best = pkg
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(name, best), "/")
+	if rest == "" {
+		rest = filepath.Base(best)
+	}
+	filename := filepath.Join(Packages[best], rest+".eso")
+	if !Exists(filename) {
+		return ""
+	}
+	return filename
+}
+
 func FindModule(name string) string {
+	if filename, ok := BundledNames[name]; ok {
+		return filename
+	}
+	if filename := findPackageModule(name); filename != "" {
+		return filename
+	}
 	basename := fmt.Sprintf("%s.eso", name)
 	for _, p := range SearchPaths {
 		filename := filepath.Join(p, basename)